@@ -8,6 +8,7 @@ require (
 	github.com/sethvargo/go-envconfig v1.3.0
 	github.com/shopspring/decimal v1.4.0
 	go.uber.org/zap v1.27.1
+	golang.org/x/image v0.18.0
 	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.31.1
 )
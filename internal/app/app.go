@@ -14,6 +14,10 @@ import (
 
 type App struct {
 	bot       *telegram.Bot
+	hub       *usecase.MarketHub
+	watcher   *usecase.EventWatcher
+	reminders *usecase.ReminderScheduler
+	recorder  *usecase.PriceRecorder
 	alerting  *usecase.AlertingManager
 	logger    *zap.Logger
 	cleanupFn func() error
@@ -32,12 +36,20 @@ func New(ctx context.Context, cfg config.Config) (*App, error) {
 
 	userRepo := db.NewUserRepository(dbConn)
 	alertRepo := db.NewAlertRepository(dbConn)
+	triggerRepo := db.NewAlertTriggerRepository(dbConn)
+	watchRepo := db.NewEventWatchRepository(dbConn)
+	reminderRepo := db.NewReminderRepository(dbConn)
+	snapshotRepo := db.NewPriceSnapshotRepository(dbConn)
 	gammaClient := polymarket.NewGammaClient(cfg.PolymarketGammaBaseURL, cfg.PolymarketGammaTimeout, logger)
+	clobClient := polymarket.NewCLOBClient(cfg.PolymarketCLOBBaseURL, cfg.PolymarketCLOBTimeout, logger)
 	wsFactory := polymarket.NewWSFactory(cfg.PolymarketWSURL, cfg.PolymarketWSReadTimeout, logger)
 
 	userUC := usecase.NewUserUsecase(userRepo)
-	alertUC := usecase.NewAlertUsecase(userRepo, alertRepo, gammaClient)
+	alertUC := usecase.NewAlertUsecase(userRepo, alertRepo, gammaClient, clobClient, logger)
 	eventUC := usecase.NewEventUsecase(gammaClient)
+	watchUC := usecase.NewEventWatchUsecase(userRepo, watchRepo, gammaClient)
+	reminderUC := usecase.NewReminderUsecase(userRepo, alertRepo, reminderRepo)
+	historyUC := usecase.NewAlertHistoryUsecase(userRepo, triggerRepo)
 
 	api, err := telegram.NewAPI(cfg.TelegramBotToken)
 	if err != nil {
@@ -45,8 +57,14 @@ func New(ctx context.Context, cfg config.Config) (*App, error) {
 	}
 
 	notifier := telegram.NewNotifier(api, logger)
-	alerting := usecase.NewAlertingManager(userRepo, alertRepo, wsFactory, notifier, logger)
-	handlers := telegram.NewHandlers(userUC, alertUC, eventUC, alerting, logger)
+	eventCache := usecase.NewEventCache(gammaClient, cfg.PolymarketEventRefresh, logger)
+	hub := usecase.NewMarketHub(wsFactory, cfg.PolymarketWSMaxAssets, cfg.PolymarketWSMinBackoff, cfg.PolymarketWSMaxBackoff, logger)
+	recorder := usecase.NewPriceRecorder(hub, snapshotRepo, cfg.PriceSnapshotInterval, cfg.PriceSnapshotRetention, logger)
+	priceUC := usecase.NewPriceUsecase(userRepo, alertRepo, gammaClient, clobClient, recorder, snapshotRepo, logger)
+	alerting := usecase.NewAlertingManager(userRepo, alertRepo, triggerRepo, reminderRepo, gammaClient, clobClient, hub, eventCache, notifier, cfg.PolymarketEventRefresh, cfg.PolymarketMarketCheck, logger)
+	watcher := usecase.NewEventWatcher(userRepo, watchRepo, eventCache, notifier, cfg.PolymarketEventPoll, logger)
+	reminders := usecase.NewReminderScheduler(userRepo, alertRepo, reminderRepo, notifier, cfg.ReminderCheckInterval, logger)
+	handlers := telegram.NewHandlers(userUC, alertUC, eventUC, watchUC, reminderUC, historyUC, priceUC, alerting, logger)
 	bot := telegram.NewBot(api, handlers, cfg.TelegramPollTimeout)
 
 	cleanup := func() error {
@@ -57,14 +75,18 @@ func New(ctx context.Context, cfg config.Config) (*App, error) {
 		return sqlDB.Close()
 	}
 
-	return &App{bot: bot, alerting: alerting, logger: logger, cleanupFn: cleanup}, nil
+	return &App{bot: bot, hub: hub, watcher: watcher, reminders: reminders, recorder: recorder, alerting: alerting, logger: logger, cleanupFn: cleanup}, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
 	a.logger.Info("botty service starting")
+	a.hub.Start(ctx)
 	if err := a.alerting.StartAll(ctx); err != nil {
 		a.logger.Warn("failed to start alerting for existing users", zap.Error(err))
 	}
+	a.watcher.Start(ctx)
+	a.reminders.Start(ctx)
+	a.recorder.Start(ctx)
 
 	a.logger.Info("botty service started")
 	return a.bot.Start(ctx)
@@ -72,7 +94,11 @@ func (a *App) Run(ctx context.Context) error {
 
 func (a *App) Shutdown() {
 	a.logger.Info("botty service shutting down")
+	a.recorder.Stop()
+	a.reminders.Stop()
+	a.watcher.Stop()
 	a.alerting.StopAll()
+	a.hub.Stop()
 	if a.cleanupFn != nil {
 		if err := a.cleanupFn(); err != nil {
 			a.logger.Warn("failed to close database", zap.Error(err))
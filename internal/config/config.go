@@ -22,7 +22,20 @@ type Config struct {
 	PolymarketWSURL         string        `env:"POLYMARKET_WS_URL,default=wss://ws-subscriptions-clob.polymarket.com/ws/market"`
 	PolymarketGammaBaseURL  string        `env:"POLYMARKET_GAMMA_BASE_URL,default=https://gamma-api.polymarket.com"`
 	PolymarketGammaTimeout  time.Duration `env:"POLYMARKET_GAMMA_TIMEOUT,default=10s"`
+	PolymarketCLOBBaseURL   string        `env:"POLYMARKET_CLOB_BASE_URL,default=https://clob.polymarket.com"`
+	PolymarketCLOBTimeout   time.Duration `env:"POLYMARKET_CLOB_TIMEOUT,default=10s"`
 	PolymarketWSReadTimeout time.Duration `env:"POLYMARKET_WS_READ_TIMEOUT,default=0s"`
+	PolymarketWSMaxAssets   int           `env:"POLYMARKET_WS_MAX_ASSETS_PER_CONN,default=500"`
+	PolymarketWSMinBackoff  time.Duration `env:"POLYMARKET_WS_RECONNECT_MIN_DELAY,default=1s"`
+	PolymarketWSMaxBackoff  time.Duration `env:"POLYMARKET_WS_RECONNECT_MAX_DELAY,default=1m"`
+	PolymarketEventRefresh  time.Duration `env:"POLYMARKET_EVENT_REFRESH_INTERVAL,default=5m"`
+	PolymarketEventPoll     time.Duration `env:"POLYMARKET_EVENT_POLL_INTERVAL,default=5m"`
+	PolymarketMarketCheck   time.Duration `env:"POLYMARKET_MARKET_CHECK_INTERVAL,default=10m"`
+
+	ReminderCheckInterval time.Duration `env:"REMINDER_CHECK_INTERVAL,default=1m"`
+
+	PriceSnapshotInterval  time.Duration `env:"PRICE_SNAPSHOT_INTERVAL,default=1m"`
+	PriceSnapshotRetention time.Duration `env:"PRICE_SNAPSHOT_RETENTION,default=192h"`
 
 	TelegramPollTimeout int    `env:"TELEGRAM_POLL_TIMEOUT,default=60"`
 	LogLevel            string `env:"LOG_LEVEL,default=info"`
@@ -0,0 +1,147 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/NasaVasa/botty/internal/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	alertPrefix = "a"
+	// alertButtonRows keeps the keyboard under Telegram's limit of 100
+	// buttons per message.
+	alertButtonRows = 30
+)
+
+// renderAlertList builds the /alerts message. confirmID marks the alert whose
+// row asks to confirm a deletion; zero renders every row normally.
+func renderAlertList(alerts []domain.Alert, confirmID uint) (string, *tgbotapi.InlineKeyboardMarkup) {
+	if len(alerts) == 0 {
+		return "No alerts yet. Use /add_alert to create one.", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Your alerts:\n")
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, min(len(alerts), alertButtonRows))
+	for i, alert := range alerts {
+		status := "disabled"
+		if alert.Enabled {
+			status = "enabled"
+		}
+		line := fmt.Sprintf("#%d [%s] %s", alert.ID, status, formatAlertRule(alert))
+		if alert.EndDate != nil {
+			line += ", ends " + alert.EndDate.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		builder.WriteString(line + "\n")
+		if i < alertButtonRows {
+			rows = append(rows, alertButtonRow(alert, alert.ID == confirmID))
+		}
+	}
+	if len(alerts) > alertButtonRows {
+		fmt.Fprintf(&builder, "Buttons are shown for the first %d alerts; use /enable, /disable and /delete for the rest.\n", alertButtonRows)
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return builder.String(), &markup
+}
+
+func alertButtonRow(alert domain.Alert, confirmDelete bool) []tgbotapi.InlineKeyboardButton {
+	if confirmDelete {
+		return tgbotapi.NewInlineKeyboardRow(
+			alertButton(fmt.Sprintf("Confirm delete #%d", alert.ID), "dely", alert.ID),
+			alertButton("Keep", "keep", alert.ID),
+		)
+	}
+
+	toggle := alertButton(fmt.Sprintf("Disable #%d", alert.ID), "off", alert.ID)
+	if !alert.Enabled {
+		toggle = alertButton(fmt.Sprintf("Enable #%d", alert.ID), "on", alert.ID)
+	}
+	row := tgbotapi.NewInlineKeyboardRow(toggle, alertButton("Delete", "del", alert.ID))
+	if alertEditable(alert) {
+		row = append(row, alertButton("Edit", "edit", alert.ID))
+	}
+	return row
+}
+
+// alertEditable reports whether the edit button can reopen the alert in the
+// /new wizard, which only builds plain price alerts on a single market.
+func alertEditable(alert domain.Alert) bool {
+	return alert.Kind == domain.AlertKindPrice && !alert.EventWide && alert.EventSlug != ""
+}
+
+func alertButton(label string, action string, alertID uint) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s:%s:%d", alertPrefix, action, alertID))
+}
+
+func (h *Handlers) handleAlertCallback(ctx context.Context, api *tgbotapi.BotAPI, userID int64, message *tgbotapi.Message, data string) {
+	action, arg, _ := strings.Cut(data, ":")
+	parsed, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil || parsed == 0 {
+		return
+	}
+	alertID := uint(parsed)
+	chatID := message.Chat.ID
+
+	var confirmID uint
+	switch action {
+	case "on":
+		err = h.alertUC.EnableAlert(ctx, userID, alertID)
+	case "off":
+		err = h.alertUC.DisableAlert(ctx, userID, alertID)
+	case "dely":
+		err = h.alertUC.DeleteAlert(ctx, userID, alertID)
+	case "del":
+		confirmID = alertID
+	case "keep":
+	case "edit":
+		h.editAlertButton(ctx, api, chatID, userID, alertID)
+		return
+	default:
+		return
+	}
+	if err != nil && !errors.Is(err, usecase.ErrAlertNotFound) {
+		h.logger.Warn("alert button failed", zap.Int64("telegram_user_id", userID), zap.String("action", action), zap.Uint("alert_id", alertID), zap.Error(err))
+		h.reply(api, chatID, h.alertErrorMessage(err))
+		return
+	}
+	if err == nil && confirmID == 0 && action != "keep" {
+		h.logger.Info("alert button complete", zap.Int64("telegram_user_id", userID), zap.String("action", action), zap.Uint("alert_id", alertID))
+		h.alerting.SyncUser(ctx, userID)
+	}
+
+	alerts, err := h.alertUC.ListAlerts(ctx, userID)
+	if err != nil {
+		h.logger.Warn("alerts list failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.reply(api, chatID, h.alertErrorMessage(err))
+		return
+	}
+	text, markup := renderAlertList(alerts, confirmID)
+	edit := tgbotapi.NewEditMessageText(chatID, message.MessageID, text)
+	edit.ReplyMarkup = markup
+	if _, err := api.Send(edit); err != nil {
+		h.logger.Warn("failed to edit alerts message", zap.Error(err))
+	}
+}
+
+func (h *Handlers) editAlertButton(ctx context.Context, api *tgbotapi.BotAPI, chatID int64, userID int64, alertID uint) {
+	alerts, err := h.alertUC.ListAlerts(ctx, userID)
+	if err != nil {
+		h.logger.Warn("alerts list failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.reply(api, chatID, h.alertErrorMessage(err))
+		return
+	}
+	for _, alert := range alerts {
+		if alert.ID == alertID && alertEditable(alert) {
+			h.startEditWizard(ctx, api, chatID, userID, alert)
+			return
+		}
+	}
+	h.reply(api, chatID, h.alertErrorMessage(usecase.ErrAlertNotFound))
+}
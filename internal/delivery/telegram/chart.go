@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/NasaVasa/botty/internal/usecase"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	chartWidth        = 800
+	chartHeight       = 450
+	chartMarginLeft   = 56
+	chartMarginRight  = 16
+	chartMarginTop    = 16
+	chartMarginBottom = 32
+	chartGridLines    = 5
+	chartTimeTicks    = 6
+	chartMinRange     = 0.02
+)
+
+var (
+	chartBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	chartGrid       = color.RGBA{R: 0xe4, G: 0xe7, B: 0xeb, A: 0xff}
+	chartAxis       = color.RGBA{R: 0x55, G: 0x5b, B: 0x66, A: 0xff}
+	chartLine       = color.RGBA{R: 0x1f, G: 0x6f, B: 0xeb, A: 0xff}
+	chartThreshold  = color.RGBA{R: 0xd9, G: 0x3b, B: 0x3b, A: 0xff}
+)
+
+// renderChart draws a price history as a step line, since each stored price
+// holds until the next one. Text that may not be ASCII, such as outcome
+// labels, belongs in the photo caption: the bitmap font only covers ASCII.
+func renderChart(history *usecase.PriceHistory) ([]byte, error) {
+	clipped := *history
+	clipped.Points = visiblePoints(history)
+	history = &clipped
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackground}, image.Point{}, draw.Src)
+
+	plot := image.Rect(chartMarginLeft, chartMarginTop, chartWidth-chartMarginRight, chartHeight-chartMarginBottom)
+	low, high := chartRange(history)
+	from, to := history.From.Unix(), history.To.Unix()
+	x := func(at time.Time) int {
+		return plot.Min.X + int(float64(at.Unix()-from)/float64(to-from)*float64(plot.Dx()))
+	}
+	y := func(price float64) int {
+		return plot.Max.Y - int((price-low)/(high-low)*float64(plot.Dy()))
+	}
+
+	for i := 0; i <= chartGridLines; i++ {
+		price := low + (high-low)*float64(i)/chartGridLines
+		row := y(price)
+		drawHLine(img, plot.Min.X, plot.Max.X, row, chartGrid, 1)
+		label := fmt.Sprintf("%.2f", price)
+		if high-low < 0.05 {
+			label = fmt.Sprintf("%.3f", price)
+		}
+		drawText(img, label, 4, row+4, chartAxis)
+	}
+	timeLayout := "15:04"
+	if history.To.Sub(history.From) > 24*time.Hour {
+		timeLayout = "01-02"
+	}
+	for i := 0; i <= chartTimeTicks; i++ {
+		at := history.From.Add(time.Duration(i) * history.To.Sub(history.From) / chartTimeTicks)
+		col := x(at)
+		drawVLine(img, col, plot.Min.Y, plot.Max.Y, chartGrid)
+		label := at.UTC().Format(timeLayout)
+		left := min(max(col-len(label)*7/2, 0), chartWidth-len(label)*7)
+		drawText(img, label, left, plot.Max.Y+18, chartAxis)
+	}
+	drawHLine(img, plot.Min.X, plot.Max.X, plot.Max.Y, chartAxis, 1)
+	drawVLine(img, plot.Min.X, plot.Min.Y, plot.Max.Y, chartAxis)
+
+	if history.Threshold != nil {
+		// A threshold outside [0, 1] is pinned to the edge of the plot.
+		threshold, _ := history.Threshold.Float64()
+		row := min(max(y(threshold), plot.Min.Y), plot.Max.Y)
+		for col := plot.Min.X; col < plot.Max.X; col += 12 {
+			drawHLine(img, col, min(col+7, plot.Max.X), row, chartThreshold, 2)
+		}
+		label := history.Comparator + " " + history.Threshold.String()
+		drawText(img, label, plot.Max.X-len(label)*7-4, row-6, chartThreshold)
+	}
+
+	points := history.Points
+	for i, point := range points {
+		price, _ := point.Price.Float64()
+		row := y(price)
+		end := history.To
+		if i+1 < len(points) {
+			end = points[i+1].At
+		}
+		drawHLine(img, x(point.At), x(end), row, chartLine, 2)
+		if i+1 < len(points) {
+			next, _ := points[i+1].Price.Float64()
+			drawVLine(img, x(end), min(row, y(next)), max(row, y(next)), chartLine)
+			drawVLine(img, x(end)+1, min(row, y(next)), max(row, y(next)), chartLine)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// visiblePoints clips a history to [From, To]. The last point before From
+// still holds the price at From, so it is moved there instead of dropped.
+func visiblePoints(history *usecase.PriceHistory) []domain.PricePoint {
+	points := make([]domain.PricePoint, 0, len(history.Points))
+	for _, point := range history.Points {
+		if point.At.After(history.To) {
+			continue
+		}
+		if point.At.Before(history.From) {
+			point.At = history.From
+			if n := len(points); n > 0 && points[n-1].At.Equal(history.From) {
+				points[n-1] = point
+				continue
+			}
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// chartRange returns the price axis bounds: the data and threshold padded by
+// a tenth of their spread, at least chartMinRange wide and within [0, 1].
+func chartRange(history *usecase.PriceHistory) (float64, float64) {
+	low, high := 1.0, 0.0
+	for _, point := range history.Points {
+		price, _ := point.Price.Float64()
+		low, high = min(low, price), max(high, price)
+	}
+	if history.Threshold != nil {
+		threshold, _ := history.Threshold.Float64()
+		low, high = min(low, threshold), max(high, threshold)
+	}
+
+	spread := high - low
+	pad := max(spread*0.1, (chartMinRange-spread)/2)
+	low, high = max(low-pad, 0), min(high+pad, 1)
+	high = max(high, low+chartMinRange)
+	low = min(low, high-chartMinRange)
+	return low, high
+}
+
+func drawHLine(img *image.RGBA, x0, x1, y int, c color.Color, width int) {
+	for dy := 0; dy < width; dy++ {
+		for x := min(x0, x1); x <= max(x0, x1); x++ {
+			img.Set(x, y+dy, c)
+		}
+	}
+}
+
+func drawVLine(img *image.RGBA, x, y0, y1 int, c color.Color) {
+	for y := min(y0, y1); y <= max(y0, y1); y++ {
+		img.Set(x, y, c)
+	}
+}
+
+func drawText(img *image.RGBA, text string, x, y int, c color.Color) {
+	drawer := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
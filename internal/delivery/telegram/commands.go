@@ -4,32 +4,279 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/NasaVasa/botty/internal/usecase"
 )
 
 const HelpText = `Commands:
 /start - register
 /help - show this help
+/new - create an alert step by step with buttons
+/cancel - cancel /new
+/search <query> [page=<n>] - find events by keyword
 /event <event_slug>
-/add_alert <event_slug> <market_slug> <YES|NO> <=|>= <threshold>
+/price <event_slug> <market> - current quote with 1h/24h change
+/chart <alert_id|event_slug market> [1h|24h|7d] - price chart
+/add_alert <event_slug> <market> <outcome> <=|>= <threshold> [src=<bid|ask|mid|last>] [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_event_alert <event_slug> <outcome|any> <=|>= <threshold> [src=<bid|ask|mid|last>] [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_move <event_slug> <market> <outcome> <up|down|any> <amount> <window> [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_spread <event_slug> <market> <outcome> <=|>= <spread> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_depth <event_slug> <market> <outcome> <bid|ask> <range> <=|>= <usd> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_trade <event_slug> <market> <outcome> <=|>= <price> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]
+/add_tick <event_slug> <market> <outcome> [once] [cooldown=<duration>]
 /alerts - list your alerts
+/history [alert_id] - recent alert triggers, including ones missed while offline
+/remind <alert_id> <before> - remind before the alert's market closes (e.g. 24h, 2d)
+/reminders - list pending reminders
+/delete_reminder <reminder_id>
+/watch_event <event_slug> - notify when markets are added, closed or resolved
+/unwatch_event <event_slug>
+/watches - list watched events
+/edit <alert_id> <field> <value> [<field> <value>...] - change threshold, comparator, cooldown, rearm (<delta> or off) or src
 /enable <alert_id>
 /disable <alert_id>
 /delete <alert_id>
 
 Notes:
-- <= alerts compare against best_ask; >= alerts compare against best_bid (fallback to price).
+- <market> is a market slug or its number from /event (e.g. /add_alert us-strikes-iran-by 2 YES >= 0.5).
+- <outcome> is any outcome label of the market (Yes/No, team names, Over/Under...); case and minor typos are ignored, quote labels with spaces: "Over 2.5".
+- <= alerts compare against best_ask; >= alerts compare against best_bid (fallback to price). src=bid|ask|mid|last overrides this per alert.
+- By default an alert fires on every update while its condition holds; cooldown=<duration> repeats it at most that often.
+- rearm: fire once when the condition becomes true and re-arm after the price moves back past the threshold by the delta (rearm=0.02; default 0).
+- cross: fire only when the price actually crosses the threshold, never on the first observed price.
+- /add_event_alert applies one price rule to every market of an event, including markets added later (e.g. /add_event_alert fed-decision-in-march any >= 0.8).
+- /add_move fires when the mid price moves by <amount> within <window>: 0.1 or 10c is absolute, 10% is relative (e.g. /add_move <event> <market> YES up 10c 15m).
+- /add_spread compares best_ask - best_bid: >= fires when the spread widens beyond the value, <= when it narrows below it.
+- /add_depth sums price*size of the bid or ask levels within <range> of the best price from the live order book (e.g. /add_depth <event> <market> YES ask 2c <= 5k).
+- /add_trade compares executed trade prices (last_trade_price) instead of quotes.
+- /add_tick notifies when the market's tick size changes, which happens when the price nears 0 or 1.
+- once: disable the alert after it fires. cooldown=10m: minimum time between triggers.
+- /edit keeps the alert ID, e.g. /edit 42 threshold 0.6 comparator <=. For /add_move the threshold is the move amount.
+- Slugs can be replaced by a polymarket.com link, e.g. /add_alert https://polymarket.com/event/<event>/<market> YES >= 0.5. Sending a link as a plain message shows that event or market.
 Example:
 /event us-strikes-iran-by
-/add_alert us-strikes-iran-by us-strikes-iran-by-june-30-2026-699-664-723-485-753-218-567-164-387-443-377-384-159-973-494-631-694-956-361-443-224-518-537-678-486-386-275-153-976-862-149 YES >= 0.5`
+/add_alert us-strikes-iran-by 1 YES >= 0.5`
 
 var ErrInvalidArguments = errors.New("invalid arguments")
 
-func ParseAddAlertArgs(args string) (eventSlug, marketSlug, outcome, comparator, threshold string, err error) {
+type AddAlertArgs struct {
+	EventSlug  string
+	MarketSlug string
+	Outcome    string
+	Comparator string
+	Threshold  string
+	Options    usecase.AlertOptions
+}
+
+func ParseAddAlertArgs(args string) (AddAlertArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	if len(parts) < 5 {
+		return AddAlertArgs{}, ErrInvalidArguments
+	}
+	options, err := ParseAlertOptions(parts[5:])
+	if err != nil {
+		return AddAlertArgs{}, err
+	}
+	return AddAlertArgs{
+		EventSlug:  strings.TrimSpace(parts[0]),
+		MarketSlug: strings.TrimSpace(parts[1]),
+		Outcome:    strings.TrimSpace(parts[2]),
+		Comparator: strings.TrimSpace(parts[3]),
+		Threshold:  strings.TrimSpace(parts[4]),
+		Options:    options,
+	}, nil
+}
+
+type AddEventAlertArgs struct {
+	EventSlug  string
+	Outcome    string
+	Comparator string
+	Threshold  string
+	Options    usecase.AlertOptions
+}
+
+func ParseAddEventAlertArgs(args string) (AddEventAlertArgs, error) {
+	parts := splitArgs(args)
+	if len(parts) < 4 {
+		return AddEventAlertArgs{}, ErrInvalidArguments
+	}
+	if link, ok := ParsePolymarketURL(parts[0]); ok {
+		parts[0] = link.EventSlug
+	}
+	options, err := ParseAlertOptions(parts[4:])
+	if err != nil {
+		return AddEventAlertArgs{}, err
+	}
+	return AddEventAlertArgs{
+		EventSlug:  strings.TrimSpace(parts[0]),
+		Outcome:    strings.TrimSpace(parts[1]),
+		Comparator: strings.TrimSpace(parts[2]),
+		Threshold:  strings.TrimSpace(parts[3]),
+		Options:    options,
+	}, nil
+}
+
+type AddTickArgs struct {
+	EventSlug  string
+	MarketSlug string
+	Outcome    string
+	Options    usecase.AlertOptions
+}
+
+func ParseAddTickArgs(args string) (AddTickArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	if len(parts) < 3 {
+		return AddTickArgs{}, ErrInvalidArguments
+	}
+	options, err := ParseAlertOptions(parts[3:])
+	if err != nil {
+		return AddTickArgs{}, err
+	}
+	return AddTickArgs{
+		EventSlug:  strings.TrimSpace(parts[0]),
+		MarketSlug: strings.TrimSpace(parts[1]),
+		Outcome:    strings.TrimSpace(parts[2]),
+		Options:    options,
+	}, nil
+}
+
+type AddMoveArgs struct {
+	EventSlug  string
+	MarketSlug string
+	Outcome    string
+	Direction  string
+	Amount     string
+	Window     string
+	Options    usecase.AlertOptions
+}
+
+func ParseAddMoveArgs(args string) (AddMoveArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	if len(parts) < 6 {
+		return AddMoveArgs{}, ErrInvalidArguments
+	}
+	options, err := ParseAlertOptions(parts[6:])
+	if err != nil {
+		return AddMoveArgs{}, err
+	}
+	return AddMoveArgs{
+		EventSlug:  strings.TrimSpace(parts[0]),
+		MarketSlug: strings.TrimSpace(parts[1]),
+		Outcome:    strings.TrimSpace(parts[2]),
+		Direction:  strings.TrimSpace(parts[3]),
+		Amount:     strings.TrimSpace(parts[4]),
+		Window:     strings.TrimSpace(parts[5]),
+		Options:    options,
+	}, nil
+}
+
+type AddDepthArgs struct {
+	EventSlug  string
+	MarketSlug string
+	Outcome    string
+	Side       string
+	Range      string
+	Comparator string
+	Threshold  string
+	Options    usecase.AlertOptions
+}
+
+func ParseAddDepthArgs(args string) (AddDepthArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	if len(parts) < 7 {
+		return AddDepthArgs{}, ErrInvalidArguments
+	}
+	options, err := ParseAlertOptions(parts[7:])
+	if err != nil {
+		return AddDepthArgs{}, err
+	}
+	return AddDepthArgs{
+		EventSlug:  strings.TrimSpace(parts[0]),
+		MarketSlug: strings.TrimSpace(parts[1]),
+		Outcome:    strings.TrimSpace(parts[2]),
+		Side:       strings.TrimSpace(parts[3]),
+		Range:      strings.TrimSpace(parts[4]),
+		Comparator: strings.TrimSpace(parts[5]),
+		Threshold:  strings.TrimSpace(parts[6]),
+		Options:    options,
+	}, nil
+}
+
+// splitArgs splits on whitespace like strings.Fields but keeps double-quoted
+// parts together so outcome labels may contain spaces.
+func splitArgs(args string) []string {
+	var parts []string
+	var current strings.Builder
+	inQuotes, hasPart := false, false
+	for _, r := range args {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			inQuotes = !inQuotes
+			hasPart = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if hasPart {
+				parts = append(parts, current.String())
+				current.Reset()
+				hasPart = false
+			}
+		default:
+			current.WriteRune(r)
+			hasPart = true
+		}
+	}
+	if hasPart {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+func ParseAlertOptions(parts []string) (usecase.AlertOptions, error) {
+	var options usecase.AlertOptions
+	for _, part := range parts {
+		key, value, hasValue := strings.Cut(strings.ToLower(strings.TrimSpace(part)), "=")
+		switch {
+		case key == "cross" && !hasValue:
+			options.Crossing = true
+		case key == "once" && !hasValue:
+			options.OneShot = true
+		case key == "cooldown" && hasValue:
+			options.Cooldown = value
+		case key == "rearm" && !hasValue:
+			options.Rearm = true
+		case key == "rearm" && hasValue:
+			options.Hysteresis = value
+		case key == "src" && hasValue:
+			options.PriceSource = value
+		default:
+			return usecase.AlertOptions{}, ErrInvalidArguments
+		}
+	}
+	return options, nil
+}
+
+type SearchArgs struct {
+	Query string
+	Page  int
+}
+
+func ParseSearchArgs(args string) (SearchArgs, error) {
 	parts := strings.Fields(args)
-	if len(parts) != 5 {
-		return "", "", "", "", "", ErrInvalidArguments
+	page := 1
+	if n := len(parts); n > 1 {
+		if value, ok := strings.CutPrefix(strings.ToLower(parts[n-1]), "page="); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				return SearchArgs{}, ErrInvalidArguments
+			}
+			page = parsed
+			parts = parts[:n-1]
+		}
 	}
-	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2]), strings.TrimSpace(parts[3]), strings.TrimSpace(parts[4]), nil
+	if len(parts) == 0 {
+		return SearchArgs{}, ErrInvalidArguments
+	}
+	return SearchArgs{Query: strings.Join(parts, " "), Page: page}, nil
 }
 
 func ParseEventSlug(args string) (string, error) {
@@ -37,9 +284,129 @@ func ParseEventSlug(args string) (string, error) {
 	if slug == "" {
 		return "", ErrInvalidArguments
 	}
+	if link, ok := ParsePolymarketURL(slug); ok {
+		return link.EventSlug, nil
+	}
 	return slug, nil
 }
 
+type MarketArgs struct {
+	EventSlug  string
+	MarketSlug string
+}
+
+// ParseMarketArgs reads "<event_slug> [market]" or a polymarket.com link. The
+// market may be omitted for single-market events.
+func ParseMarketArgs(args string) (MarketArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	switch len(parts) {
+	case 1:
+		return MarketArgs{EventSlug: parts[0]}, nil
+	case 2:
+		return MarketArgs{EventSlug: parts[0], MarketSlug: parts[1]}, nil
+	default:
+		return MarketArgs{}, ErrInvalidArguments
+	}
+}
+
+type ChartArgs struct {
+	AlertID uint
+	Market  MarketArgs
+	Period  string
+}
+
+// ParseChartArgs reads "<alert_id> [period]" or "<event_slug> [market]
+// [period]", where the period is a duration like 1h, 24h or 7d.
+func ParseChartArgs(args string) (ChartArgs, error) {
+	parts := expandLinkArgs(splitArgs(args))
+	var chart ChartArgs
+	if n := len(parts); n > 1 && isPeriod(parts[n-1]) {
+		chart.Period = parts[n-1]
+		parts = parts[:n-1]
+	}
+	switch len(parts) {
+	case 1:
+		if alertID, err := strconv.ParseUint(parts[0], 10, 64); err == nil {
+			chart.AlertID = uint(alertID)
+			return chart, nil
+		}
+		chart.Market = MarketArgs{EventSlug: parts[0]}
+	case 2:
+		chart.Market = MarketArgs{EventSlug: parts[0], MarketSlug: parts[1]}
+	default:
+		return ChartArgs{}, ErrInvalidArguments
+	}
+	return chart, nil
+}
+
+func isPeriod(value string) bool {
+	value = strings.ToLower(value)
+	number, ok := strings.CutSuffix(value, "h")
+	if !ok {
+		number, ok = strings.CutSuffix(value, "d")
+	}
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(number)
+	return err == nil
+}
+
+type RemindArgs struct {
+	AlertID uint
+	Before  string
+}
+
+func ParseRemindArgs(args string) (RemindArgs, error) {
+	parts := strings.Fields(args)
+	if len(parts) != 2 {
+		return RemindArgs{}, ErrInvalidArguments
+	}
+	alertID, err := ParseAlertID(parts[0])
+	if err != nil {
+		return RemindArgs{}, err
+	}
+	return RemindArgs{AlertID: alertID, Before: parts[1]}, nil
+}
+
+type EditArgs struct {
+	AlertID uint
+	Update  usecase.AlertUpdate
+}
+
+// ParseEditArgs reads "<alert_id> <field> <value>..." where fields are
+// threshold, comparator, cooldown, rearm and src.
+func ParseEditArgs(args string) (EditArgs, error) {
+	parts := strings.Fields(args)
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return EditArgs{}, ErrInvalidArguments
+	}
+	alertID, err := ParseAlertID(parts[0])
+	if err != nil {
+		return EditArgs{}, err
+	}
+
+	var update usecase.AlertUpdate
+	for i := 1; i < len(parts); i += 2 {
+		value := parts[i+1]
+		switch strings.ToLower(parts[i]) {
+		case "threshold", "amount":
+			update.Threshold = value
+		case "comparator":
+			update.Comparator = value
+		case "cooldown":
+			update.Cooldown = value
+		case "rearm":
+			update.Hysteresis = value
+		case "src":
+			update.PriceSource = value
+		default:
+			return EditArgs{}, ErrInvalidArguments
+		}
+	}
+	return EditArgs{AlertID: alertID, Update: update}, nil
+}
+
 func ParseAlertID(args string) (uint, error) {
 	idStr := strings.TrimSpace(args)
 	if idStr == "" {
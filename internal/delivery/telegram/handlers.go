@@ -5,26 +5,37 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/NasaVasa/botty/internal/domain"
 	"github.com/NasaVasa/botty/internal/usecase"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
 type Handlers struct {
-	userUC   *usecase.UserUsecase
-	alertUC  *usecase.AlertUsecase
-	eventUC  *usecase.EventUsecase
-	alerting *usecase.AlertingManager
-	logger   *zap.Logger
+	userUC     *usecase.UserUsecase
+	alertUC    *usecase.AlertUsecase
+	eventUC    *usecase.EventUsecase
+	watchUC    *usecase.EventWatchUsecase
+	reminderUC *usecase.ReminderUsecase
+	historyUC  *usecase.AlertHistoryUsecase
+	priceUC    *usecase.PriceUsecase
+	wizards    *wizardStore
+	alerting   *usecase.AlertingManager
+	logger     *zap.Logger
 }
 
-func NewHandlers(userUC *usecase.UserUsecase, alertUC *usecase.AlertUsecase, eventUC *usecase.EventUsecase, alerting *usecase.AlertingManager, logger *zap.Logger) *Handlers {
-	return &Handlers{userUC: userUC, alertUC: alertUC, eventUC: eventUC, alerting: alerting, logger: logger}
+func NewHandlers(userUC *usecase.UserUsecase, alertUC *usecase.AlertUsecase, eventUC *usecase.EventUsecase, watchUC *usecase.EventWatchUsecase, reminderUC *usecase.ReminderUsecase, historyUC *usecase.AlertHistoryUsecase, priceUC *usecase.PriceUsecase, alerting *usecase.AlertingManager, logger *zap.Logger) *Handlers {
+	return &Handlers{userUC: userUC, alertUC: alertUC, eventUC: eventUC, watchUC: watchUC, reminderUC: reminderUC, historyUC: historyUC, priceUC: priceUC, wizards: newWizardStore(), alerting: alerting, logger: logger}
 }
 
 func (h *Handlers) HandleUpdate(ctx context.Context, api *tgbotapi.BotAPI, update tgbotapi.Update) {
+	if update.CallbackQuery != nil {
+		h.handleCallback(ctx, api, update.CallbackQuery)
+		return
+	}
 	if update.Message == nil {
 		return
 	}
@@ -35,6 +46,55 @@ func (h *Handlers) HandleUpdate(ctx context.Context, api *tgbotapi.BotAPI, updat
 		h.handleCommand(ctx, api, update)
 		return
 	}
+	if update.Message.Chat.IsPrivate() {
+		if h.handleWizardText(ctx, api, update.Message.From.ID, update.Message.Text) {
+			return
+		}
+		if link, ok := FindPolymarketLink(update.Message.Text); ok {
+			h.handleLink(ctx, api, update.Message.Chat.ID, update.Message.From.ID, link)
+		}
+	}
+}
+
+func (h *Handlers) handleLink(ctx context.Context, api *tgbotapi.BotAPI, chatID int64, userID int64, link PolymarketLink) {
+	h.logger.Info(
+		"telegram link received",
+		zap.Int64("telegram_user_id", userID),
+		zap.String("event_slug", link.EventSlug),
+		zap.String("market_slug", link.MarketSlug),
+	)
+
+	event, err := h.eventUC.GetEvent(ctx, link.EventSlug)
+	if err != nil {
+		h.logger.Warn("link lookup failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.reply(api, chatID, h.alertErrorMessage(err))
+		return
+	}
+	if link.MarketSlug == "" {
+		h.reply(api, chatID, formatEventSummary(link.EventSlug, event))
+		return
+	}
+	for i, market := range event.Markets {
+		if market.Slug != link.MarketSlug {
+			continue
+		}
+		outcome := "YES"
+		if len(market.Outcomes) > 0 {
+			outcome = market.Outcomes[0]
+		}
+		text := formatMarketBlock(i+1, market)
+		// splitArgs has no escapes, so a label with a quote cannot be typed
+		// back as one argument.
+		if !strings.ContainsAny(outcome, `"“”`) {
+			if strings.IndexFunc(outcome, unicode.IsSpace) >= 0 {
+				outcome = `"` + outcome + `"`
+			}
+			text += fmt.Sprintf("Create an alert:\n/add_alert %s %d %s >= 0.5", link.EventSlug, i+1, outcome)
+		}
+		h.reply(api, chatID, text)
+		return
+	}
+	h.reply(api, chatID, h.alertErrorMessage(usecase.ErrMarketNotInEvent))
 }
 
 func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, update tgbotapi.Update) {
@@ -66,6 +126,23 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 	case "help":
 		h.logger.Info("help command complete", zap.Int64("telegram_user_id", userID))
 		h.reply(api, chatID, HelpText)
+	case "new":
+		h.startWizard(ctx, api, chatID, userID, args)
+	case "cancel":
+		h.cancelWizard(api, chatID, userID)
+	case "search":
+		parsed, err := ParseSearchArgs(args)
+		if err != nil {
+			h.reply(api, chatID, "Usage: /search <query> [page=<n>]")
+			return
+		}
+		result, err := h.eventUC.SearchEvents(ctx, parsed.Query, parsed.Page)
+		if err != nil {
+			h.logger.Warn("search failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.reply(api, chatID, formatSearchResult(parsed.Query, result))
 	case "event":
 		eventSlug, err := ParseEventSlug(args)
 		if err != nil {
@@ -78,22 +155,291 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 			return
 		}
 		h.reply(api, chatID, formatEventSummary(eventSlug, event))
+	case "price":
+		parsed, err := ParseMarketArgs(args)
+		if err != nil {
+			h.logger.Warn("price invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /price <event_slug> <market>")
+			return
+		}
+		quote, err := h.priceUC.GetMarketQuote(ctx, parsed.EventSlug, parsed.MarketSlug)
+		if err != nil {
+			h.logger.Warn("price failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("price complete", zap.Int64("telegram_user_id", userID), zap.String("market_slug", quote.Market.Slug))
+		h.reply(api, chatID, formatMarketQuote(quote))
+	case "chart":
+		parsed, err := ParseChartArgs(args)
+		if err != nil {
+			h.logger.Warn("chart invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /chart <alert_id> [1h|24h|7d] or /chart <event_slug> <market> [1h|24h|7d]")
+			return
+		}
+		var history *usecase.PriceHistory
+		if parsed.AlertID != 0 {
+			history, err = h.priceUC.AlertPriceHistory(ctx, userID, parsed.AlertID, parsed.Period)
+		} else {
+			history, err = h.priceUC.MarketPriceHistory(ctx, parsed.Market.EventSlug, parsed.Market.MarketSlug, parsed.Period)
+		}
+		if err != nil {
+			h.logger.Warn("chart failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		chart, err := renderChart(history)
+		if err != nil {
+			h.logger.Error("chart render failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, "Failed to draw the chart. Try again later.")
+			return
+		}
+		h.logger.Info("chart complete", zap.Int64("telegram_user_id", userID), zap.String("market_slug", history.MarketSlug), zap.Int("points", len(history.Points)))
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "chart.png", Bytes: chart})
+		photo.Caption = formatChartCaption(history)
+		if _, err := api.Send(photo); err != nil {
+			h.logger.Warn("failed to send chart", zap.Error(err))
+		}
 	case "add_alert":
-		eventSlug, marketSlug, outcome, comparator, threshold, err := ParseAddAlertArgs(args)
+		parsed, err := ParseAddAlertArgs(args)
 		if err != nil {
 			h.logger.Warn("add_alert invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
-			h.reply(api, chatID, "Usage: /add_alert <event_slug> <market_slug> <YES|NO> <=|>= <threshold>")
+			h.reply(api, chatID, "Usage: /add_alert <event_slug> <market> <outcome> <=|>= <threshold> [src=<bid|ask|mid|last>] [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]")
 			return
 		}
-		alert, err := h.alertUC.AddAlert(ctx, userID, eventSlug, marketSlug, outcome, comparator, threshold)
+		alert, err := h.alertUC.AddAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Comparator, parsed.Threshold, parsed.Options)
 		if err != nil {
 			h.logger.Warn("add_alert failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
 			h.reply(api, chatID, h.alertErrorMessage(err))
 			return
 		}
 		h.logger.Info("add_alert complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
-		h.alerting.RestartUser(ctx, userID)
-		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s %s %s %s", alert.ID, alert.MarketSlug, alert.Outcome, alert.Comparator, alert.Threshold))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_event_alert":
+		parsed, err := ParseAddEventAlertArgs(args)
+		if err != nil {
+			h.logger.Warn("add_event_alert invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_event_alert <event_slug> <outcome|any> <=|>= <threshold> [src=<bid|ask|mid|last>] [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]")
+			return
+		}
+		alert, err := h.alertUC.AddEventAlert(ctx, userID, parsed.EventSlug, parsed.Outcome, parsed.Comparator, parsed.Threshold, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_event_alert failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_event_alert complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_move":
+		parsed, err := ParseAddMoveArgs(args)
+		if err != nil {
+			h.logger.Warn("add_move invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_move <event_slug> <market> <outcome> <up|down|any> <amount> <window> [once] [cooldown=<duration>] [rearm[=<delta>]]")
+			return
+		}
+		alert, err := h.alertUC.AddMoveAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Direction, parsed.Amount, parsed.Window, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_move failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_move complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_spread":
+		parsed, err := ParseAddAlertArgs(args)
+		if err != nil {
+			h.logger.Warn("add_spread invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_spread <event_slug> <market> <outcome> <=|>= <spread> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]")
+			return
+		}
+		alert, err := h.alertUC.AddSpreadAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Comparator, parsed.Threshold, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_spread failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_spread complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_depth":
+		parsed, err := ParseAddDepthArgs(args)
+		if err != nil {
+			h.logger.Warn("add_depth invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_depth <event_slug> <market> <outcome> <bid|ask> <range> <=|>= <usd> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]")
+			return
+		}
+		alert, err := h.alertUC.AddDepthAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Side, parsed.Range, parsed.Comparator, parsed.Threshold, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_depth failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_depth complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_trade":
+		parsed, err := ParseAddAlertArgs(args)
+		if err != nil {
+			h.logger.Warn("add_trade invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_trade <event_slug> <market> <outcome> <=|>= <price> [cross] [once] [cooldown=<duration>] [rearm[=<delta>]]")
+			return
+		}
+		alert, err := h.alertUC.AddTradeAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Comparator, parsed.Threshold, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_trade failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_trade complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "add_tick":
+		parsed, err := ParseAddTickArgs(args)
+		if err != nil {
+			h.logger.Warn("add_tick invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /add_tick <event_slug> <market> <outcome> [once] [cooldown=<duration>]")
+			return
+		}
+		alert, err := h.alertUC.AddTickAlert(ctx, userID, parsed.EventSlug, parsed.MarketSlug, parsed.Outcome, parsed.Options)
+		if err != nil {
+			h.logger.Warn("add_tick failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("add_tick complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+	case "watch_event":
+		eventSlug, err := ParseEventSlug(args)
+		if err != nil {
+			h.logger.Warn("watch_event invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /watch_event <event_slug>")
+			return
+		}
+		watch, err := h.watchUC.WatchEvent(ctx, userID, eventSlug)
+		if err != nil {
+			h.logger.Warn("watch_event failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("watch_event complete", zap.Int64("telegram_user_id", userID), zap.String("event_slug", watch.EventSlug))
+		h.reply(api, chatID, fmt.Sprintf("Watching %s (%d markets). You will be notified when markets are added, closed or resolved.", watch.EventSlug, len(watch.Markets)))
+	case "unwatch_event":
+		eventSlug, err := ParseEventSlug(args)
+		if err != nil {
+			h.logger.Warn("unwatch_event invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /unwatch_event <event_slug>")
+			return
+		}
+		if err := h.watchUC.UnwatchEvent(ctx, userID, eventSlug); err != nil {
+			h.logger.Warn("unwatch_event failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("unwatch_event complete", zap.Int64("telegram_user_id", userID), zap.String("event_slug", eventSlug))
+		h.reply(api, chatID, fmt.Sprintf("Stopped watching %s.", eventSlug))
+	case "watches":
+		watches, err := h.watchUC.ListWatches(ctx, userID)
+		if err != nil {
+			h.logger.Warn("watches list failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		if len(watches) == 0 {
+			h.reply(api, chatID, "No watched events. Use /watch_event <event_slug> to add one.")
+			return
+		}
+		var builder strings.Builder
+		builder.WriteString("Watched events:\n")
+		for _, watch := range watches {
+			builder.WriteString(fmt.Sprintf("%s (%d markets)\n", watch.EventSlug, len(watch.Markets)))
+		}
+		h.reply(api, chatID, builder.String())
+	case "history":
+		var alertID uint
+		if strings.TrimSpace(args) != "" {
+			parsed, err := ParseAlertID(args)
+			if err != nil {
+				h.logger.Warn("history invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+				h.reply(api, chatID, "Usage: /history [alert_id]")
+				return
+			}
+			alertID = parsed
+		}
+		triggers, err := h.historyUC.ListTriggers(ctx, userID, alertID)
+		if err != nil {
+			h.logger.Warn("history failed", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alertID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("history complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alertID), zap.Int("count", len(triggers)))
+		if len(triggers) == 0 {
+			if alertID != 0 {
+				h.reply(api, chatID, fmt.Sprintf("Alert #%d has not fired yet.", alertID))
+				return
+			}
+			h.reply(api, chatID, "None of your alerts has fired yet.")
+			return
+		}
+		var builder strings.Builder
+		if alertID != 0 {
+			builder.WriteString(fmt.Sprintf("Latest triggers of alert #%d:\n", alertID))
+		} else {
+			builder.WriteString("Latest alert triggers:\n")
+		}
+		for _, trigger := range triggers {
+			builder.WriteString(formatTrigger(trigger) + "\n")
+		}
+		h.reply(api, chatID, builder.String())
+	case "remind":
+		parsed, err := ParseRemindArgs(args)
+		if err != nil {
+			h.logger.Warn("remind invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /remind <alert_id> <before> (e.g. /remind 3 24h)")
+			return
+		}
+		reminder, err := h.reminderUC.AddReminder(ctx, userID, parsed.AlertID, parsed.Before)
+		if err != nil {
+			h.logger.Warn("remind failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("remind complete", zap.Int64("telegram_user_id", userID), zap.Uint("reminder_id", reminder.ID))
+		h.reply(api, chatID, fmt.Sprintf("Reminder #%d set: %s", reminder.ID, formatReminder(*reminder)))
+	case "reminders":
+		reminders, err := h.reminderUC.ListReminders(ctx, userID)
+		if err != nil {
+			h.logger.Warn("reminders list failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		if len(reminders) == 0 {
+			h.reply(api, chatID, "No pending reminders. Use /remind <alert_id> <before> to add one.")
+			return
+		}
+		var builder strings.Builder
+		builder.WriteString("Your reminders:\n")
+		for _, reminder := range reminders {
+			builder.WriteString(fmt.Sprintf("#%d %s\n", reminder.ID, formatReminder(reminder)))
+		}
+		h.reply(api, chatID, builder.String())
+	case "delete_reminder":
+		reminderID, err := ParseAlertID(args)
+		if err != nil {
+			h.logger.Warn("delete_reminder invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /delete_reminder <reminder_id>")
+			return
+		}
+		if err := h.reminderUC.DeleteReminder(ctx, userID, reminderID); err != nil {
+			h.logger.Warn("delete_reminder failed", zap.Int64("telegram_user_id", userID), zap.Uint("reminder_id", reminderID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("delete_reminder complete", zap.Int64("telegram_user_id", userID), zap.Uint("reminder_id", reminderID))
+		h.reply(api, chatID, fmt.Sprintf("Reminder #%d deleted.", reminderID))
 	case "alerts":
 		alerts, err := h.alertUC.ListAlerts(ctx, userID)
 		if err != nil {
@@ -107,16 +453,12 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 			return
 		}
 		h.logger.Info("alerts list complete", zap.Int64("telegram_user_id", userID), zap.Int("count", len(alerts)))
-		var builder strings.Builder
-		builder.WriteString("Your alerts:\n")
-		for _, alert := range alerts {
-			status := "disabled"
-			if alert.Enabled {
-				status = "enabled"
-			}
-			builder.WriteString(fmt.Sprintf("#%d [%s] %s %s %s %s\n", alert.ID, status, alert.MarketSlug, alert.Outcome, alert.Comparator, alert.Threshold))
+		text, markup := renderAlertList(alerts, 0)
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ReplyMarkup = markup
+		if _, err := api.Send(msg); err != nil {
+			h.logger.Warn("failed to send message", zap.Error(err))
 		}
-		h.reply(api, chatID, builder.String())
 	case "enable":
 		alertID, err := ParseAlertID(args)
 		if err != nil {
@@ -130,7 +472,7 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 			return
 		}
 		h.logger.Info("enable complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alertID))
-		h.alerting.RestartUser(ctx, userID)
+		h.alerting.SyncUser(ctx, userID)
 		h.reply(api, chatID, fmt.Sprintf("Alert #%d enabled.", alertID))
 	case "disable":
 		alertID, err := ParseAlertID(args)
@@ -145,8 +487,24 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 			return
 		}
 		h.logger.Info("disable complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alertID))
-		h.alerting.RestartUser(ctx, userID)
+		h.alerting.SyncUser(ctx, userID)
 		h.reply(api, chatID, fmt.Sprintf("Alert #%d disabled.", alertID))
+	case "edit":
+		editArgs, err := ParseEditArgs(args)
+		if err != nil {
+			h.logger.Warn("edit invalid args", zap.Int64("telegram_user_id", userID), zap.String("args", args))
+			h.reply(api, chatID, "Usage: /edit <alert_id> <field> <value>\nFields: threshold, comparator, cooldown, rearm (<delta> or off), src.")
+			return
+		}
+		alert, err := h.alertUC.UpdateAlert(ctx, userID, editArgs.AlertID, editArgs.Update)
+		if err != nil {
+			h.logger.Warn("edit failed", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", editArgs.AlertID), zap.Error(err))
+			h.reply(api, chatID, h.alertErrorMessage(err))
+			return
+		}
+		h.logger.Info("edit complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+		h.alerting.SyncUser(ctx, userID)
+		h.reply(api, chatID, fmt.Sprintf("Alert #%d updated: %s", alert.ID, formatAlertRule(*alert)))
 	case "delete":
 		alertID, err := ParseAlertID(args)
 		if err != nil {
@@ -160,7 +518,7 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 			return
 		}
 		h.logger.Info("delete complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alertID))
-		h.alerting.RestartUser(ctx, userID)
+		h.alerting.SyncUser(ctx, userID)
 		h.reply(api, chatID, fmt.Sprintf("Alert #%d deleted.", alertID))
 	default:
 		h.logger.Warn("unknown command", zap.Int64("telegram_user_id", userID), zap.String("command", command))
@@ -169,11 +527,14 @@ func (h *Handlers) handleCommand(ctx context.Context, api *tgbotapi.BotAPI, upda
 }
 
 func (h *Handlers) alertErrorMessage(err error) string {
+	var outcomeErr *usecase.OutcomeError
 	switch {
 	case errors.Is(err, usecase.ErrUserNotRegistered):
 		return "Please /start to register first."
+	case errors.As(err, &outcomeErr):
+		return fmt.Sprintf("Unknown outcome %q. Valid outcomes: %s.", outcomeErr.Outcome, strings.Join(outcomeErr.Valid, ", "))
 	case errors.Is(err, usecase.ErrInvalidOutcome):
-		return "Invalid outcome. Use YES or NO."
+		return "Invalid outcome. Use /event <event_slug> to list outcomes."
 	case errors.Is(err, usecase.ErrInvalidComparator):
 		return "Invalid comparator. Use <=, >=, <, or >."
 	case errors.Is(err, usecase.ErrInvalidThreshold):
@@ -184,12 +545,239 @@ func (h *Handlers) alertErrorMessage(err error) string {
 		return "Event not found. Ensure the slug is correct."
 	case errors.Is(err, usecase.ErrMarketNotInEvent):
 		return "Market not found in that event. Use /event <event_slug> to list markets."
+	case errors.Is(err, usecase.ErrInvalidCooldown):
+		return "Invalid cooldown. Use a duration like 30s, 10m or 1h."
+	case errors.Is(err, usecase.ErrInvalidHysteresis):
+		return "Invalid rearm delta. Use a non-negative decimal like 0.02."
+	case errors.Is(err, usecase.ErrInvalidDirection):
+		return "Invalid direction. Use up, down or any."
+	case errors.Is(err, usecase.ErrInvalidMoveAmount):
+		return "Invalid move amount. Use 0.1, 10c or 10%."
+	case errors.Is(err, usecase.ErrInvalidWindow):
+		return "Invalid window. Use a duration between 1s and 24h like 15m."
+	case errors.Is(err, usecase.ErrInvalidBookSide):
+		return "Invalid book side. Use bid or ask."
+	case errors.Is(err, usecase.ErrInvalidDepthRange):
+		return "Invalid range. Use a price distance like 0.02 or 2c."
+	case errors.Is(err, usecase.ErrCrossUnsupported):
+		return "The cross option is not supported for this alert type."
+	case errors.Is(err, usecase.ErrRearmUnsupported):
+		return "The rearm option is not supported for tick alerts."
+	case errors.Is(err, usecase.ErrAlreadyWatching):
+		return "You are already watching this event."
+	case errors.Is(err, usecase.ErrWatchNotFound):
+		return "You are not watching this event. Use /watches to list watched events."
+	case errors.Is(err, usecase.ErrInvalidReminder):
+		return "Invalid reminder time. Use a duration like 30m, 24h or 2d."
+	case errors.Is(err, usecase.ErrNoEndDate):
+		return "This alert's market has no end date, so a reminder cannot be scheduled."
+	case errors.Is(err, usecase.ErrReminderInPast):
+		return "That reminder time has already passed."
+	case errors.Is(err, usecase.ErrReminderNotFound):
+		return "Reminder not found."
+	case errors.Is(err, usecase.ErrInvalidQuery):
+		return "Search query is empty."
+	case errors.Is(err, usecase.ErrMarketClosed):
+		return "Closed or resolved markets no longer trade, so the alert would never fire."
+	case errors.Is(err, usecase.ErrNoEventMarkets):
+		return "This event has no markets yet."
+	case errors.Is(err, usecase.ErrInvalidPriceSource):
+		return "Invalid price source. Use bid, ask, mid, last or auto."
+	case errors.Is(err, usecase.ErrPriceSourceUnsupported):
+		return "The src option is only supported for price alerts."
+	case errors.Is(err, usecase.ErrNoOrderBook):
+		return "This market has no order book on Polymarket, so there are no prices to watch."
+	case errors.Is(err, usecase.ErrInvalidPeriod):
+		return "Invalid period. Use 1h, 24h or 7d."
+	case errors.Is(err, usecase.ErrNoPriceHistory):
+		return "No price history found for this market."
+	case errors.Is(err, usecase.ErrChartNotSupported):
+		return "Charts are not available for event-wide alerts. Use /chart <event_slug> <market> instead."
+	case errors.Is(err, usecase.ErrFieldNotEditable):
+		return "This field cannot be changed for this kind of alert."
+	case errors.Is(err, usecase.ErrEmptyUpdate):
+		return "Nothing to change."
 	}
 
 	h.logger.Warn("unhandled error", zap.Error(err))
 	return "Something went wrong. Please try again."
 }
 
+func formatAlertRule(alert domain.Alert) string {
+	var rule string
+	switch {
+	case alert.EventWide:
+		outcome := alert.Outcome
+		if outcome == "" {
+			outcome = "any outcome"
+		}
+		rule = fmt.Sprintf("event %s %s %s %s", alert.EventSlug, outcome, alert.Comparator, alert.Threshold)
+	case alert.Kind == domain.AlertKindMove:
+		amount := alert.Threshold
+		if alert.Relative {
+			amount += "%"
+		}
+		rule = fmt.Sprintf("%s %s move %s %s in %s", alert.MarketSlug, alert.Outcome, alert.Direction, amount, alert.Window)
+	case alert.Kind == domain.AlertKindSpread:
+		rule = fmt.Sprintf("%s %s spread %s %s", alert.MarketSlug, alert.Outcome, alert.Comparator, alert.Threshold)
+	case alert.Kind == domain.AlertKindTrade:
+		rule = fmt.Sprintf("%s %s last trade %s %s", alert.MarketSlug, alert.Outcome, alert.Comparator, alert.Threshold)
+	case alert.Kind == domain.AlertKindTick:
+		rule = fmt.Sprintf("%s %s tick size change", alert.MarketSlug, alert.Outcome)
+	case alert.Kind == domain.AlertKindDepth:
+		rule = fmt.Sprintf("%s %s %s depth within %s %s $%s", alert.MarketSlug, alert.Outcome, alert.BookSide, alert.DepthRange, alert.Comparator, alert.Threshold)
+	default:
+		rule = fmt.Sprintf("%s %s %s %s", alert.MarketSlug, alert.Outcome, alert.Comparator, alert.Threshold)
+	}
+	if alert.Kind == domain.AlertKindPrice && alert.PriceSource != "" && alert.PriceSource != domain.PriceSourceAuto {
+		rule += " src=" + alert.PriceSource
+	}
+	if alert.Trigger == domain.TriggerCross {
+		rule += " cross"
+	}
+	if alert.OneShot {
+		rule += " once"
+	}
+	if alert.Cooldown > 0 {
+		rule += " cooldown=" + alert.Cooldown.String()
+	}
+	if alert.Rearm {
+		rule += " rearm"
+		if alert.Hysteresis != "" && alert.Hysteresis != "0" {
+			rule += "=" + alert.Hysteresis
+		}
+	}
+	return rule
+}
+
+func formatMarketQuote(quote *usecase.MarketQuote) string {
+	var builder strings.Builder
+	title := strings.TrimSpace(quote.Market.Question)
+	if title == "" {
+		title = quote.Market.Slug
+	}
+	builder.WriteString(title + "\n")
+	for _, outcome := range quote.Quotes {
+		fmt.Fprintf(&builder, "%s: bid %s, ask %s, last %s (%s)\n", outcome.Outcome, decimalOrNA(outcome.BestBid), decimalOrNA(outcome.BestAsk), decimalOrNA(outcome.LastTrade), outcome.Source)
+		fmt.Fprintf(&builder, "  1h %s, 24h %s\n", formatChange(outcome.Change1h), formatChange(outcome.Change24h))
+	}
+	return builder.String()
+}
+
+func decimalOrNA(value *decimal.Decimal) string {
+	if value == nil {
+		return "n/a"
+	}
+	return value.String()
+}
+
+func formatChange(change *decimal.Decimal) string {
+	if change == nil {
+		return "n/a"
+	}
+	if change.IsPositive() {
+		return "+" + change.String()
+	}
+	return change.String()
+}
+
+func formatChartCaption(history *usecase.PriceHistory) string {
+	caption := fmt.Sprintf("%s %s, mid price over %s (UTC)", history.MarketSlug, history.Outcome, history.Period)
+	if history.Source == usecase.HistorySourceCLOB {
+		caption = fmt.Sprintf("%s %s, price over %s (UTC, CLOB history)", history.MarketSlug, history.Outcome, history.Period)
+	}
+	if history.AlertID != 0 {
+		caption += fmt.Sprintf("\nAlert #%d", history.AlertID)
+		if history.Threshold != nil {
+			caption += fmt.Sprintf(": %s %s (dashed line)", history.Comparator, history.Threshold.String())
+		}
+	}
+	return caption
+}
+
+func formatTrigger(trigger domain.AlertTrigger) string {
+	line := fmt.Sprintf("%s #%d %s %s", trigger.TriggeredAt.UTC().Format("2006-01-02 15:04:05 UTC"), trigger.AlertID, trigger.MarketSlug, trigger.Outcome)
+	switch trigger.Kind {
+	case domain.AlertKindSpread:
+		line += " spread " + trigger.Price
+	case domain.AlertKindDepth:
+		line += " depth $" + trigger.Price
+	case domain.AlertKindTick:
+		line += " tick " + trigger.Price
+	default:
+		line += " at " + trigger.Price
+	}
+	if trigger.BestBid != "" || trigger.BestAsk != "" {
+		line += fmt.Sprintf(" (bid %s ask %s)", valueOrNA(trigger.BestBid), valueOrNA(trigger.BestAsk))
+	}
+	if trigger.Status == domain.DeliveryFailed {
+		line += " - not delivered"
+	}
+	return line
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return "n/a"
+	}
+	return value
+}
+
+func formatReminder(reminder domain.Reminder) string {
+	return fmt.Sprintf(
+		"%s before %s closes (%s), alert #%d, at %s",
+		usecase.FormatOffset(reminder.Before),
+		reminder.MarketSlug,
+		reminder.EndDate.UTC().Format("2006-01-02 15:04 UTC"),
+		reminder.AlertID,
+		reminder.RemindAt.UTC().Format("2006-01-02 15:04 UTC"),
+	)
+}
+
+func formatSearchResult(query string, result *domain.EventSearchResult) string {
+	if len(result.Events) == 0 {
+		if result.Page > 1 {
+			return fmt.Sprintf("No more events for %q.", query)
+		}
+		return fmt.Sprintf("No events found for %q.", query)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Events for %q (page %d", query, result.Page))
+	if result.Total > 0 {
+		builder.WriteString(fmt.Sprintf(", %d total", result.Total))
+	}
+	builder.WriteString("):\n")
+	for i, event := range result.Events {
+		details := []string{event.Slug}
+		if event.Volume != nil {
+			details = append(details, "vol "+formatVolume(*event.Volume))
+		}
+		if event.Closed {
+			details = append(details, "closed")
+		} else if event.EndDate != nil {
+			details = append(details, "ends "+event.EndDate.UTC().Format("2006-01-02"))
+		}
+		builder.WriteString(fmt.Sprintf("%d) %s\n%s\n\n", i+1, event.Title, strings.Join(details, " | ")))
+	}
+	builder.WriteString("Use /event <event_slug> to list markets.")
+	if result.HasMore {
+		builder.WriteString(fmt.Sprintf("\nMore: /search %s page=%d", query, result.Page+1))
+	}
+	return builder.String()
+}
+
+func formatVolume(volume decimal.Decimal) string {
+	switch {
+	case volume.GreaterThanOrEqual(decimal.NewFromInt(1_000_000)):
+		return "$" + volume.Div(decimal.NewFromInt(1_000_000)).StringFixed(1) + "M"
+	case volume.GreaterThanOrEqual(decimal.NewFromInt(1_000)):
+		return "$" + volume.Div(decimal.NewFromInt(1_000)).StringFixed(1) + "K"
+	default:
+		return "$" + volume.StringFixed(0)
+	}
+}
+
 func formatEventSummary(requestedSlug string, event *domain.EventMarkets) string {
 	const maxMessageLen = 3800
 
@@ -198,7 +786,7 @@ func formatEventSummary(requestedSlug string, event *domain.EventMarkets) string
 		eventSlug = requestedSlug
 	}
 
-	header := fmt.Sprintf("Event: %s\nMarkets:\n", eventSlug)
+	header := fmt.Sprintf("Event: %s\nMarkets (the number can be used as <market> in /add_alert):\n", eventSlug)
 	var builder strings.Builder
 	builder.WriteString(header)
 	remaining := 0
@@ -236,7 +824,14 @@ func formatMarketBlock(index int, market domain.MarketInfo) string {
 
 func formatPriceSummary(market domain.MarketInfo) string {
 	if len(market.OutcomePrices) >= 2 {
-		return fmt.Sprintf("Price: YES %s$ NO %s$", market.OutcomePrices[0], market.OutcomePrices[1])
+		if len(market.Outcomes) != len(market.OutcomePrices) {
+			return fmt.Sprintf("Price: YES %s$ NO %s$", market.OutcomePrices[0], market.OutcomePrices[1])
+		}
+		prices := make([]string, len(market.Outcomes))
+		for i, outcome := range market.Outcomes {
+			prices[i] = fmt.Sprintf("%s %s$", outcome, market.OutcomePrices[i])
+		}
+		return "Price: " + strings.Join(prices, " ")
 	}
 	bid := "N/A"
 	ask := "N/A"
@@ -0,0 +1,86 @@
+package telegram
+
+import (
+	"net/url"
+	"strings"
+)
+
+type PolymarketLink struct {
+	EventSlug  string
+	MarketSlug string
+}
+
+// ParsePolymarketURL extracts slugs from links like
+// https://polymarket.com/event/<event>/<market>?tid=... The market part is
+// optional. Any other input is rejected.
+func ParsePolymarketURL(raw string) (PolymarketLink, bool) {
+	raw = strings.Trim(strings.TrimSpace(raw), "<>()[]\"'.,")
+	if !strings.Contains(raw, "://") {
+		if !strings.HasPrefix(strings.ToLower(raw), "polymarket.com/") && !strings.HasPrefix(strings.ToLower(raw), "www.polymarket.com/") {
+			return PolymarketLink{}, false
+		}
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return PolymarketLink{}, false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host != "polymarket.com" {
+		return PolymarketLink{}, false
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	// Localized links carry a language prefix, e.g. /ru/event/<slug>.
+	if len(segments) > 1 && len(segments[0]) == 2 && segments[1] == "event" {
+		segments = segments[1:]
+	}
+	if len(segments) < 2 || segments[0] != "event" || segments[1] == "" {
+		return PolymarketLink{}, false
+	}
+
+	link := PolymarketLink{EventSlug: segments[1]}
+	if len(segments) > 2 {
+		link.MarketSlug = segments[2]
+	}
+	return link, true
+}
+
+// FindPolymarketLink returns the first Polymarket event link in free text.
+func FindPolymarketLink(text string) (PolymarketLink, bool) {
+	for _, field := range strings.Fields(text) {
+		if link, ok := ParsePolymarketURL(field); ok {
+			return link, true
+		}
+	}
+	return PolymarketLink{}, false
+}
+
+// expandLinkArgs replaces Polymarket links at the start of an argument list
+// with the slugs they carry, so "<url> YES >= 0.5" reads like
+// "<event> <market> YES >= 0.5". A market link in the second position is
+// reduced to its market slug.
+func expandLinkArgs(parts []string) []string {
+	if len(parts) == 0 {
+		return parts
+	}
+
+	expanded := make([]string, 0, len(parts)+1)
+	rest := parts[1:]
+	if link, ok := ParsePolymarketURL(parts[0]); ok {
+		expanded = append(expanded, link.EventSlug)
+		if link.MarketSlug != "" {
+			expanded = append(expanded, link.MarketSlug)
+		}
+	} else {
+		expanded = append(expanded, parts[0])
+	}
+	if len(expanded) == 1 && len(rest) > 0 {
+		if link, ok := ParsePolymarketURL(rest[0]); ok && link.MarketSlug != "" {
+			expanded = append(expanded, link.MarketSlug)
+			rest = rest[1:]
+		}
+	}
+	return append(expanded, rest...)
+}
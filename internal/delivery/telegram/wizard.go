@@ -0,0 +1,502 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/NasaVasa/botty/internal/usecase"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	wizardTimeout      = 10 * time.Minute
+	wizardPageSize     = 8
+	wizardButtonMaxLen = 60
+	wizardPrefix       = "w"
+)
+
+type wizardStep int
+
+const (
+	stepEvent wizardStep = iota
+	stepPickEvent
+	stepMarket
+	stepOutcome
+	stepComparator
+	stepThreshold
+)
+
+// wizardState is the server-side conversation state of one user's /new flow.
+// Callback data only carries indexes into it, which keeps buttons well under
+// Telegram's 64-byte limit no matter how long the slugs are.
+type wizardState struct {
+	step      wizardStep
+	chatID    int64
+	messageID int
+	events    []domain.EventSummary
+	eventSlug string
+	markets   []domain.MarketInfo
+	// marketNumbers holds each market's 1-based position in the event, the
+	// number /event shows and <market> accepts.
+	marketNumbers []int
+	marketPage    int
+	market        domain.MarketInfo
+	outcome       string
+	comparator    string
+	// editID is the alert an edit started from. Its comparator and threshold
+	// are updated in place, so it keeps its ID, history and reminders.
+	editID uint
+	// fresh makes the next render post a new message instead of editing the
+	// previous one, so replies to typed input appear below it.
+	fresh     bool
+	expiresAt time.Time
+}
+
+type wizardStore struct {
+	mu     sync.Mutex
+	states map[int64]*wizardState
+}
+
+func newWizardStore() *wizardStore {
+	return &wizardStore{states: make(map[int64]*wizardState)}
+}
+
+// get returns the active state of a user. expired is true when the user had a
+// wizard that timed out; the state is dropped in that case.
+func (s *wizardStore) get(userID int64, now time.Time) (state *wizardState, expired bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, candidate := range s.states {
+		if now.After(candidate.expiresAt) {
+			delete(s.states, id)
+			if id == userID {
+				expired = true
+			}
+		}
+	}
+	return s.states[userID], expired
+}
+
+func (s *wizardStore) put(userID int64, state *wizardState, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state.expiresAt = now.Add(wizardTimeout)
+	s.states[userID] = state
+}
+
+func (s *wizardStore) delete(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, userID)
+}
+
+func (h *Handlers) startWizard(ctx context.Context, api *tgbotapi.BotAPI, chatID int64, userID int64, args string) {
+	state := &wizardState{step: stepEvent, chatID: chatID}
+	if strings.TrimSpace(args) != "" {
+		h.wizardEventInput(ctx, api, userID, state, args)
+		return
+	}
+	state.fresh = true
+	h.wizardRender(api, userID, state, "New alert: send an event slug, a polymarket.com link or search words.", cancelKeyboard())
+}
+
+// startEditWizard reopens an existing price alert at the comparator step of
+// its market. The outcome stays fixed: only fields /edit changes in place are
+// offered, since another outcome would need a new alert.
+func (h *Handlers) startEditWizard(ctx context.Context, api *tgbotapi.BotAPI, chatID int64, userID int64, alert domain.Alert) {
+	state := &wizardState{step: stepEvent, chatID: chatID, fresh: true, editID: alert.ID}
+	event, err := h.eventUC.GetEvent(ctx, alert.EventSlug)
+	if err != nil {
+		h.logger.Warn("wizard event lookup failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.reply(api, chatID, h.alertErrorMessage(err))
+		return
+	}
+
+	state.eventSlug = alert.EventSlug
+	state.markets, state.marketNumbers = openEventMarkets(event)
+	for _, market := range state.markets {
+		if market.Slug == alert.MarketSlug {
+			state.market = market
+			state.outcome = alert.Outcome
+			state.step = stepComparator
+			h.wizardRender(api, userID, state, fmt.Sprintf("Editing alert #%d: %s %s %s %s\nAlert when the price:", alert.ID, market.Slug, alert.Outcome, alert.Comparator, alert.Threshold), comparatorKeyboard())
+			return
+		}
+	}
+	h.reply(api, chatID, h.alertErrorMessage(usecase.ErrMarketClosed))
+}
+
+func (h *Handlers) cancelWizard(api *tgbotapi.BotAPI, chatID int64, userID int64) {
+	state, _ := h.wizards.get(userID, time.Now())
+	h.wizards.delete(userID)
+	if state == nil {
+		h.reply(api, chatID, "Nothing to cancel.")
+		return
+	}
+	h.wizardEdit(api, state, "Alert creation cancelled.", nil)
+}
+
+// handleWizardText consumes a plain-text message when the user's wizard waits
+// for typed input. It reports false when the message is not meant for it.
+func (h *Handlers) handleWizardText(ctx context.Context, api *tgbotapi.BotAPI, userID int64, text string) bool {
+	state, expired := h.wizards.get(userID, time.Now())
+	if expired {
+		h.reply(api, userID, "The alert wizard timed out. Send /new to start again.")
+		return true
+	}
+	if state == nil {
+		return false
+	}
+
+	state.fresh = true
+	switch state.step {
+	case stepEvent, stepPickEvent:
+		h.wizardEventInput(ctx, api, userID, state, text)
+	case stepThreshold:
+		h.wizardThresholdInput(ctx, api, userID, state, text)
+	default:
+		h.reply(api, state.chatID, "Please use the buttons above, or /cancel.")
+	}
+	return true
+}
+
+func (h *Handlers) handleCallback(ctx context.Context, api *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery) {
+	if _, err := api.Request(tgbotapi.NewCallback(query.ID, "")); err != nil {
+		h.logger.Warn("failed to answer callback", zap.Error(err))
+	}
+	if query.From == nil || query.Message == nil {
+		return
+	}
+	userID := query.From.ID
+	h.logger.Info("telegram callback received", zap.Int64("telegram_user_id", userID), zap.String("data", query.Data))
+
+	prefix, data, _ := strings.Cut(query.Data, ":")
+	switch prefix {
+	case wizardPrefix:
+		h.handleWizardCallback(ctx, api, userID, query.Message, data)
+	case alertPrefix:
+		h.handleAlertCallback(ctx, api, userID, query.Message, data)
+	default:
+		h.logger.Warn("unknown callback", zap.Int64("telegram_user_id", userID), zap.String("data", query.Data))
+	}
+}
+
+func (h *Handlers) handleWizardCallback(ctx context.Context, api *tgbotapi.BotAPI, userID int64, message *tgbotapi.Message, data string) {
+	state, expired := h.wizards.get(userID, time.Now())
+	if state == nil || state.messageID != message.MessageID {
+		text := "This wizard is no longer active. Send /new to start again."
+		if expired {
+			text = "The alert wizard timed out. Send /new to start again."
+		}
+		edit := tgbotapi.NewEditMessageText(message.Chat.ID, message.MessageID, text)
+		if _, err := api.Send(edit); err != nil {
+			h.logger.Warn("failed to edit wizard message", zap.Error(err))
+		}
+		return
+	}
+
+	action, arg, _ := strings.Cut(data, ":")
+	if action == "x" {
+		h.wizards.delete(userID)
+		h.wizardEdit(api, state, "Alert creation cancelled.", nil)
+		return
+	}
+	index, err := strconv.Atoi(arg)
+	if action != "c" && err != nil {
+		return
+	}
+
+	switch {
+	case action == "e" && state.step == stepPickEvent && index >= 0 && index < len(state.events):
+		h.wizardSelectEvent(ctx, api, userID, state, state.events[index].Slug)
+	case action == "mp" && state.step == stepMarket:
+		state.marketPage = index
+		h.wizardShowMarkets(api, userID, state)
+	case action == "m" && state.step == stepMarket && index >= 0 && index < len(state.markets):
+		h.wizardSelectMarket(api, userID, state, state.markets[index])
+	case action == "o" && state.step == stepOutcome && index >= 0 && index < len(wizardOutcomes(state.market)):
+		state.outcome = wizardOutcomes(state.market)[index]
+		state.step = stepComparator
+		h.wizardRender(api, userID, state, fmt.Sprintf("%s %s\nAlert when the price:", state.market.Slug, state.outcome), comparatorKeyboard())
+	case action == "c" && state.step == stepComparator && (arg == ">=" || arg == "<="):
+		state.comparator = arg
+		state.step = stepThreshold
+		h.wizardRender(api, userID, state, fmt.Sprintf("%s %s %s ?\n%sType the threshold price, e.g. 0.55.", state.market.Slug, state.outcome, state.comparator, currentPriceLine(state.market, state.outcome)), cancelKeyboard())
+	}
+}
+
+func (h *Handlers) wizardEventInput(ctx context.Context, api *tgbotapi.BotAPI, userID int64, state *wizardState, text string) {
+	text = strings.TrimSpace(text)
+	if link, ok := FindPolymarketLink(text); ok {
+		h.wizardSelectEvent(ctx, api, userID, state, link.EventSlug)
+		return
+	}
+	if !strings.ContainsAny(text, " \t\n") {
+		event, err := h.eventUC.GetEvent(ctx, text)
+		if err == nil {
+			h.wizardShowEvent(api, userID, state, text, event)
+			return
+		}
+		if !errors.Is(err, usecase.ErrEventNotFound) {
+			h.logger.Warn("wizard event lookup failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+			h.wizardRender(api, userID, state, h.alertErrorMessage(err), cancelKeyboard())
+			return
+		}
+	}
+
+	result, err := h.eventUC.SearchEvents(ctx, text, 1)
+	if err != nil {
+		h.logger.Warn("wizard search failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.wizardRender(api, userID, state, h.alertErrorMessage(err), cancelKeyboard())
+		return
+	}
+	if len(result.Events) == 0 {
+		h.wizardRender(api, userID, state, fmt.Sprintf("No events found for %q. Try other words or a link.", text), cancelKeyboard())
+		return
+	}
+
+	state.events = result.Events
+	state.step = stepPickEvent
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, len(result.Events)+1)
+	for i, event := range result.Events {
+		label := event.Title
+		if label == "" {
+			label = event.Slug
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(wizardButton(label, "e", i)))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(cancelButton()))
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	h.wizardRender(api, userID, state, "Pick an event:", &markup)
+}
+
+func (h *Handlers) wizardSelectEvent(ctx context.Context, api *tgbotapi.BotAPI, userID int64, state *wizardState, eventSlug string) {
+	event, err := h.eventUC.GetEvent(ctx, eventSlug)
+	if err != nil {
+		h.logger.Warn("wizard event lookup failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		h.wizardRender(api, userID, state, h.alertErrorMessage(err), cancelKeyboard())
+		return
+	}
+	h.wizardShowEvent(api, userID, state, eventSlug, event)
+}
+
+func (h *Handlers) wizardShowEvent(api *tgbotapi.BotAPI, userID int64, state *wizardState, eventSlug string, event *domain.EventMarkets) {
+	markets, numbers := openEventMarkets(event)
+	if len(markets) == 0 {
+		state.step = stepEvent
+		h.wizardRender(api, userID, state, "This event has no open markets. Send another event.", cancelKeyboard())
+		return
+	}
+
+	state.eventSlug = eventSlug
+	state.events = nil
+	state.markets = markets
+	state.marketNumbers = numbers
+	state.marketPage = 0
+	state.step = stepMarket
+	if len(markets) == 1 {
+		h.wizardSelectMarket(api, userID, state, markets[0])
+		return
+	}
+	h.wizardShowMarkets(api, userID, state)
+}
+
+func (h *Handlers) wizardShowMarkets(api *tgbotapi.BotAPI, userID int64, state *wizardState) {
+	pages := (len(state.markets) + wizardPageSize - 1) / wizardPageSize
+	page := min(max(state.marketPage, 0), pages-1)
+	start := page * wizardPageSize
+	end := min(start+wizardPageSize, len(state.markets))
+
+	rows := make([][]tgbotapi.InlineKeyboardButton, 0, end-start+2)
+	for i := start; i < end; i++ {
+		market := state.markets[i]
+		label := strings.TrimSpace(market.Question)
+		if label == "" {
+			label = market.Slug
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(wizardButton(fmt.Sprintf("%d) %s", state.marketNumbers[i], label), "m", i)))
+	}
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, wizardButton("‹ Prev", "mp", page-1))
+	}
+	if page < pages-1 {
+		nav = append(nav, wizardButton("Next ›", "mp", page+1))
+	}
+	nav = append(nav, cancelButton())
+	rows = append(rows, nav)
+
+	text := fmt.Sprintf("Event: %s\nPick a market", state.eventSlug)
+	if pages > 1 {
+		text += fmt.Sprintf(" (page %d/%d)", page+1, pages)
+	}
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	h.wizardRender(api, userID, state, text+":", &markup)
+}
+
+func (h *Handlers) wizardSelectMarket(api *tgbotapi.BotAPI, userID int64, state *wizardState, market domain.MarketInfo) {
+	state.market = market
+	state.step = stepOutcome
+
+	var buttons []tgbotapi.InlineKeyboardButton
+	for i, outcome := range wizardOutcomes(market) {
+		buttons = append(buttons, wizardButton(outcome, "o", i))
+	}
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	for len(buttons) > 0 {
+		n := min(len(buttons), 2)
+		rows = append(rows, buttons[:n])
+		buttons = buttons[n:]
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(cancelButton()))
+	markup := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	h.wizardRender(api, userID, state, formatMarketBlock(marketNumber(state, market), market)+"Pick an outcome:", &markup)
+}
+
+func (h *Handlers) wizardThresholdInput(ctx context.Context, api *tgbotapi.BotAPI, userID int64, state *wizardState, text string) {
+	if state.editID != 0 {
+		h.wizardUpdateAlert(ctx, api, userID, state, text)
+		return
+	}
+	alert, err := h.alertUC.AddAlert(ctx, userID, state.eventSlug, state.market.Slug, state.outcome, state.comparator, text, usecase.AlertOptions{})
+	if err != nil {
+		h.logger.Warn("wizard add_alert failed", zap.Int64("telegram_user_id", userID), zap.Error(err))
+		if errors.Is(err, usecase.ErrInvalidThreshold) {
+			h.wizardRender(api, userID, state, h.alertErrorMessage(err)+" Type the threshold again.", cancelKeyboard())
+			return
+		}
+		h.wizards.delete(userID)
+		h.reply(api, state.chatID, h.alertErrorMessage(err))
+		return
+	}
+
+	h.wizards.delete(userID)
+	h.logger.Info("wizard add_alert complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+	h.alerting.SyncUser(ctx, userID)
+	h.wizardEdit(api, state, fmt.Sprintf("%s %s %s %s", state.market.Slug, state.outcome, state.comparator, strings.TrimSpace(text)), nil)
+	h.reply(api, state.chatID, fmt.Sprintf("Alert created: #%d %s", alert.ID, formatAlertRule(*alert)))
+}
+
+func (h *Handlers) wizardUpdateAlert(ctx context.Context, api *tgbotapi.BotAPI, userID int64, state *wizardState, text string) {
+	update := usecase.AlertUpdate{Comparator: state.comparator, Threshold: text}
+	alert, err := h.alertUC.UpdateAlert(ctx, userID, state.editID, update)
+	if err != nil {
+		h.logger.Warn("wizard edit failed", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", state.editID), zap.Error(err))
+		if errors.Is(err, usecase.ErrInvalidThreshold) {
+			h.wizardRender(api, userID, state, h.alertErrorMessage(err)+" Type the threshold again.", cancelKeyboard())
+			return
+		}
+		h.wizards.delete(userID)
+		h.reply(api, state.chatID, h.alertErrorMessage(err))
+		return
+	}
+
+	h.wizards.delete(userID)
+	h.logger.Info("wizard edit complete", zap.Int64("telegram_user_id", userID), zap.Uint("alert_id", alert.ID))
+	h.alerting.SyncUser(ctx, userID)
+	h.wizardEdit(api, state, fmt.Sprintf("%s %s %s %s", state.market.Slug, state.outcome, state.comparator, strings.TrimSpace(text)), nil)
+	h.reply(api, state.chatID, fmt.Sprintf("Alert #%d updated: %s", alert.ID, formatAlertRule(*alert)))
+}
+
+// wizardRender shows the current step and saves the state. It edits the
+// active wizard message, or posts a new one that buttons then refer to.
+func (h *Handlers) wizardRender(api *tgbotapi.BotAPI, userID int64, state *wizardState, text string, markup *tgbotapi.InlineKeyboardMarkup) {
+	defer h.wizards.put(userID, state, time.Now())
+	if !state.fresh && state.messageID != 0 {
+		h.wizardEdit(api, state, text, markup)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(state.chatID, text)
+	if markup != nil {
+		msg.ReplyMarkup = *markup
+	}
+	sent, err := api.Send(msg)
+	if err != nil {
+		h.logger.Warn("failed to send wizard message", zap.Error(err))
+		return
+	}
+	state.messageID = sent.MessageID
+	state.fresh = false
+}
+
+func (h *Handlers) wizardEdit(api *tgbotapi.BotAPI, state *wizardState, text string, markup *tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(state.chatID, state.messageID, text)
+	edit.ReplyMarkup = markup
+	if _, err := api.Send(edit); err != nil {
+		h.logger.Warn("failed to edit wizard message", zap.Error(err))
+	}
+}
+
+// openEventMarkets returns the markets the wizard offers together with their
+// positions in the event, which closed markets keep occupying.
+func openEventMarkets(event *domain.EventMarkets) ([]domain.MarketInfo, []int) {
+	markets := make([]domain.MarketInfo, 0, len(event.Markets))
+	numbers := make([]int, 0, len(event.Markets))
+	for i, market := range event.Markets {
+		if !market.Closed && !market.Resolved {
+			markets = append(markets, market)
+			numbers = append(numbers, i+1)
+		}
+	}
+	return markets, numbers
+}
+
+func marketNumber(state *wizardState, market domain.MarketInfo) int {
+	for i, candidate := range state.markets {
+		if candidate.Slug == market.Slug {
+			return state.marketNumbers[i]
+		}
+	}
+	return 1
+}
+
+func wizardOutcomes(market domain.MarketInfo) []string {
+	if len(market.Outcomes) == 0 {
+		return []string{"Yes", "No"}
+	}
+	return market.Outcomes
+}
+
+func currentPriceLine(market domain.MarketInfo, outcome string) string {
+	for i, label := range market.Outcomes {
+		if label == outcome && i < len(market.OutcomePrices) {
+			return fmt.Sprintf("Current price: %s\n", market.OutcomePrices[i])
+		}
+	}
+	return ""
+}
+
+func wizardButton(label string, action string, index int) tgbotapi.InlineKeyboardButton {
+	if runes := []rune(label); len(runes) > wizardButtonMaxLen {
+		label = string(runes[:wizardButtonMaxLen-3]) + "..."
+	}
+	return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s:%s:%d", wizardPrefix, action, index))
+}
+
+func cancelButton() tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData("Cancel", wizardPrefix+":x")
+}
+
+func cancelKeyboard() *tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(cancelButton()))
+	return &markup
+}
+
+func comparatorKeyboard() *tgbotapi.InlineKeyboardMarkup {
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(">= rises to", wizardPrefix+":c:>="),
+			tgbotapi.NewInlineKeyboardButtonData("<= falls to", wizardPrefix+":c:<="),
+		),
+		tgbotapi.NewInlineKeyboardRow(cancelButton()),
+	)
+	return &markup
+}
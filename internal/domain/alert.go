@@ -2,15 +2,70 @@ package domain
 
 import "time"
 
+const (
+	AlertKindPrice  = "price"
+	AlertKindMove   = "move"
+	AlertKindSpread = "spread"
+	AlertKindDepth  = "depth"
+	AlertKindTrade  = "trade"
+	AlertKindTick   = "tick"
+)
+
+const (
+	BookSideBid = "bid"
+	BookSideAsk = "ask"
+)
+
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+	DirectionAny  = "any"
+)
+
+const (
+	PriceSourceAuto = "auto"
+	PriceSourceBid  = "bid"
+	PriceSourceAsk  = "ask"
+	PriceSourceMid  = "mid"
+	PriceSourceLast = "last"
+)
+
+const (
+	TriggerLevel = "level"
+	TriggerCross = "cross"
+)
+
+const (
+	SideAbove = "above"
+	SideBelow = "below"
+)
+
 type Alert struct {
 	ID          uint
 	UserID      uint
+	EventSlug   string
+	EventWide   bool
 	MarketSlug  string
 	ConditionID string
 	Outcome     string
 	AssetID     string
+	Kind        string
 	Comparator  string
 	Threshold   string
+	PriceSource string
+	Direction   string
+	Window      time.Duration
+	Relative    bool
+	BookSide    string
+	DepthRange  string
+	OneShot     bool
+	Cooldown    time.Duration
+	Hysteresis  string
+	Rearm       bool
+	Trigger     string
+	LastSide    string
+	LastFiredAt *time.Time
+	EndDate     *time.Time
 	Enabled     bool
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
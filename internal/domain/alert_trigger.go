@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+const (
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// AlertTrigger is one firing of an alert. Price is the observed value the
+// rule compared against; BestBid and BestAsk are empty when the event that
+// fired the alert carried no quote.
+type AlertTrigger struct {
+	ID          uint
+	UserID      uint
+	AlertID     uint
+	MarketSlug  string
+	Outcome     string
+	AssetID     string
+	Kind        string
+	Price       string
+	BestBid     string
+	BestAsk     string
+	Status      string
+	Error       string
+	TriggeredAt time.Time
+}
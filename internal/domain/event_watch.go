@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+const (
+	MarketStatusOpen     = "open"
+	MarketStatusClosed   = "closed"
+	MarketStatusResolved = "resolved"
+)
+
+// EventWatch subscribes a user to market changes of a Gamma event. Markets
+// holds the last seen status per market slug and is the baseline for diffs.
+type EventWatch struct {
+	ID        uint
+	UserID    uint
+	EventSlug string
+	Markets   map[string]string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
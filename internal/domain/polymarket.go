@@ -3,11 +3,16 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
 
-var ErrEventNotFound = errors.New("event not found")
+var (
+	ErrEventNotFound  = errors.New("event not found")
+	ErrMarketNotFound = errors.New("market not found")
+	ErrBookNotFound   = errors.New("order book not found")
+)
 
 type MarketInfo struct {
 	Slug          string
@@ -19,32 +24,117 @@ type MarketInfo struct {
 	BestAsk       *decimal.Decimal
 	LastTrade     *decimal.Decimal
 	OutcomePrices []string
+	Active        bool
+	Closed        bool
+	Resolved      bool
+	EndDate       *time.Time
 }
 
 type EventMarkets struct {
 	EventSlug string
+	Title     string
 	Markets   []MarketInfo
 }
 
+type EventSummary struct {
+	Slug    string
+	Title   string
+	Volume  *decimal.Decimal
+	Active  bool
+	Closed  bool
+	EndDate *time.Time
+}
+
+type EventSearchResult struct {
+	Events  []EventSummary
+	Page    int
+	HasMore bool
+	Total   int
+}
+
 type GammaClient interface {
 	GetEventBySlug(ctx context.Context, slug string) (*EventMarkets, error)
+	GetMarketBySlug(ctx context.Context, slug string) (*MarketInfo, error)
+	SearchEvents(ctx context.Context, query string, page, limit int) (*EventSearchResult, error)
+}
+
+const (
+	EventTypePriceChange = "price_change"
+	EventTypeBook        = "book"
+	EventTypeLastTrade   = "last_trade_price"
+	EventTypeTickSize    = "tick_size_change"
+)
+
+type PricePoint struct {
+	At    time.Time
+	Price decimal.Decimal
+}
+
+// CLOBClient reads current quotes and price history of outcome tokens from
+// the public Polymarket CLOB REST API. Tokens without an order book report
+// ErrBookNotFound.
+type CLOBClient interface {
+	GetPrice(ctx context.Context, tokenID, side string) (decimal.Decimal, error)
+	GetMidpoint(ctx context.Context, tokenID string) (decimal.Decimal, error)
+	GetSpread(ctx context.Context, tokenID string) (decimal.Decimal, error)
+	GetOrderBook(ctx context.Context, tokenID string) (*OrderBook, error)
+	GetPriceHistory(ctx context.Context, tokenID string, from, to time.Time, fidelity time.Duration) ([]PricePoint, error)
 }
 
+const (
+	OrderSideBuy  = "BUY"
+	OrderSideSell = "SELL"
+)
+
 type PriceChange struct {
 	AssetID string
 	BestBid *decimal.Decimal
 	BestAsk *decimal.Decimal
 	Price   *decimal.Decimal
+	Size    *decimal.Decimal
+	Side    string
+}
+
+type OrderLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+type OrderBook struct {
+	AssetID   string
+	Bids      []OrderLevel
+	Asks      []OrderLevel
+	Timestamp time.Time
+}
+
+type LastTrade struct {
+	AssetID   string
+	Price     decimal.Decimal
+	Size      *decimal.Decimal
+	Side      string
+	Timestamp time.Time
+}
+
+type TickSizeChange struct {
+	AssetID     string
+	OldTickSize decimal.Decimal
+	NewTickSize decimal.Decimal
+	Timestamp   time.Time
 }
 
-type PriceChangeMessage struct {
-	EventType    string
-	PriceChanges []PriceChange
+type MarketEvent struct {
+	EventType      string
+	AssetID        string
+	PriceChange    *PriceChange
+	Book           *OrderBook
+	LastTrade      *LastTrade
+	TickSizeChange *TickSizeChange
 }
 
 type MarketWSClient interface {
 	Subscribe(ctx context.Context, assetIDs []string) error
-	Receive(ctx context.Context) (*PriceChangeMessage, error)
+	Unsubscribe(ctx context.Context, assetIDs []string) error
+	Receive(ctx context.Context) ([]MarketEvent, error)
 	Close() error
 }
 
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceSnapshot is a sampled quote of one outcome token. Fields the stream
+// has not reported yet are nil.
+type PriceSnapshot struct {
+	ID        uint
+	AssetID   string
+	BestBid   *decimal.Decimal
+	BestAsk   *decimal.Decimal
+	LastTrade *decimal.Decimal
+	TakenAt   time.Time
+}
+
+// Mid returns the bid/ask midpoint, or the last trade when one side of the
+// book is unknown.
+func (s PriceSnapshot) Mid() *decimal.Decimal {
+	if s.BestBid != nil && s.BestAsk != nil {
+		mid := s.BestBid.Add(*s.BestAsk).Div(decimal.NewFromInt(2))
+		return &mid
+	}
+	return s.LastTrade
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Reminder notifies a user Before the end date of an alert's market.
+type Reminder struct {
+	ID         uint
+	UserID     uint
+	AlertID    uint
+	MarketSlug string
+	EndDate    time.Time
+	Before     time.Duration
+	RemindAt   time.Time
+	SentAt     *time.Time
+	CreatedAt  time.Time
+}
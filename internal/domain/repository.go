@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 var ErrNotFound = errors.New("not found")
@@ -18,6 +19,39 @@ type AlertRepository interface {
 	ListByUser(ctx context.Context, userID uint) ([]Alert, error)
 	ListEnabledByUser(ctx context.Context, userID uint) ([]Alert, error)
 	SetEnabled(ctx context.Context, userID uint, alertID uint, enabled bool) error
+	SetLastSide(ctx context.Context, userID uint, alertID uint, side string) error
+	SetLastFired(ctx context.Context, userID uint, alertID uint, firedAt time.Time) error
+	SetEndDate(ctx context.Context, alertID uint, endDate time.Time) error
+	Update(ctx context.Context, alert *Alert) error
 	Delete(ctx context.Context, userID uint, alertID uint) error
 	ListUserIDsWithEnabledAlerts(ctx context.Context) ([]uint, error)
 }
+
+type AlertTriggerRepository interface {
+	Create(ctx context.Context, trigger *AlertTrigger) error
+	ListByUser(ctx context.Context, userID uint, alertID uint, limit int) ([]AlertTrigger, error)
+}
+
+type PriceSnapshotRepository interface {
+	CreateBatch(ctx context.Context, snapshots []PriceSnapshot) error
+	LatestBefore(ctx context.Context, assetID string, at time.Time) (*PriceSnapshot, error)
+	ListRange(ctx context.Context, assetID string, from, to time.Time) ([]PriceSnapshot, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *Reminder) error
+	ListByUser(ctx context.Context, userID uint) ([]Reminder, error)
+	ListDue(ctx context.Context, now time.Time) ([]Reminder, error)
+	Reschedule(ctx context.Context, alertID uint, endDate time.Time) error
+	MarkSent(ctx context.Context, reminderID uint, sentAt time.Time) error
+	Delete(ctx context.Context, userID uint, reminderID uint) error
+}
+
+type EventWatchRepository interface {
+	Create(ctx context.Context, watch *EventWatch) error
+	ListByUser(ctx context.Context, userID uint) ([]EventWatch, error)
+	ListAll(ctx context.Context) ([]EventWatch, error)
+	UpdateMarkets(ctx context.Context, watchID uint, markets map[string]string) error
+	Delete(ctx context.Context, userID uint, eventSlug string) error
+}
@@ -58,6 +58,62 @@ func (r *AlertRepository) SetEnabled(ctx context.Context, userID uint, alertID u
 	return nil
 }
 
+func (r *AlertRepository) SetLastSide(ctx context.Context, userID uint, alertID uint, side string) error {
+	result := r.db.WithContext(ctx).Model(&alertModel{}).Where("id = ? AND user_id = ?", alertID, userID).UpdateColumn("last_side", side)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetLastFired records when an alert last notified, so cooldowns survive a
+// restart. Like SetLastSide it leaves updated_at alone.
+func (r *AlertRepository) SetLastFired(ctx context.Context, userID uint, alertID uint, firedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&alertModel{}).Where("id = ? AND user_id = ?", alertID, userID).UpdateColumn("last_fired_at", firedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AlertRepository) SetEndDate(ctx context.Context, alertID uint, endDate time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&alertModel{}).
+		Where("id = ? AND (end_date IS NULL OR end_date <> ?)", alertID, endDate).
+		UpdateColumn("end_date", endDate).Error
+}
+
+// Update writes the editable fields of an alert. updated_at changes with them,
+// which tells the alerting manager to drop the alert's evaluation state.
+func (r *AlertRepository) Update(ctx context.Context, alert *domain.Alert) error {
+	result := r.db.WithContext(ctx).
+		Model(&alertModel{}).
+		Where("id = ? AND user_id = ?", alert.ID, alert.UserID).
+		Updates(map[string]any{
+			"comparator":   alert.Comparator,
+			"threshold":    alert.Threshold,
+			"relative":     alert.Relative,
+			"price_source": alert.PriceSource,
+			"cooldown":     alert.Cooldown,
+			"hysteresis":   alert.Hysteresis,
+			"rearm":        alert.Rearm,
+			"last_side":    alert.LastSide,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 func (r *AlertRepository) Delete(ctx context.Context, userID uint, alertID uint) error {
 	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", alertID, userID).Delete(&alertModel{})
 	if result.Error != nil {
@@ -92,12 +148,29 @@ func mapAlertsToDomain(models []alertModel) []domain.Alert {
 		alerts = append(alerts, domain.Alert{
 			ID:          model.ID,
 			UserID:      model.UserID,
+			EventSlug:   model.EventSlug,
+			EventWide:   model.EventWide,
 			MarketSlug:  model.MarketSlug,
 			ConditionID: model.ConditionID,
 			Outcome:     model.Outcome,
 			AssetID:     model.AssetID,
+			Kind:        model.Kind,
 			Comparator:  model.Comparator,
 			Threshold:   model.Threshold,
+			PriceSource: model.PriceSource,
+			Direction:   model.Direction,
+			Window:      model.Window,
+			Relative:    model.Relative,
+			BookSide:    model.BookSide,
+			DepthRange:  model.DepthRange,
+			OneShot:     model.OneShot,
+			Cooldown:    model.Cooldown,
+			Hysteresis:  model.Hysteresis,
+			Rearm:       model.Rearm,
+			Trigger:     model.Trigger,
+			LastSide:    model.LastSide,
+			LastFiredAt: model.LastFiredAt,
+			EndDate:     model.EndDate,
 			Enabled:     model.Enabled,
 			CreatedAt:   model.CreatedAt,
 			UpdatedAt:   model.UpdatedAt,
@@ -111,12 +184,29 @@ func mapAlertToModel(alert domain.Alert) alertModel {
 	return alertModel{
 		ID:          alert.ID,
 		UserID:      alert.UserID,
+		EventSlug:   alert.EventSlug,
+		EventWide:   alert.EventWide,
 		MarketSlug:  alert.MarketSlug,
 		ConditionID: alert.ConditionID,
 		Outcome:     alert.Outcome,
 		AssetID:     alert.AssetID,
+		Kind:        alert.Kind,
 		Comparator:  alert.Comparator,
 		Threshold:   alert.Threshold,
+		PriceSource: alert.PriceSource,
+		Direction:   alert.Direction,
+		Window:      alert.Window,
+		Relative:    alert.Relative,
+		BookSide:    alert.BookSide,
+		DepthRange:  alert.DepthRange,
+		OneShot:     alert.OneShot,
+		Cooldown:    alert.Cooldown,
+		Hysteresis:  alert.Hysteresis,
+		Rearm:       alert.Rearm,
+		Trigger:     alert.Trigger,
+		LastSide:    alert.LastSide,
+		LastFiredAt: alert.LastFiredAt,
+		EndDate:     alert.EndDate,
 		Enabled:     alert.Enabled,
 		CreatedAt:   alert.CreatedAt,
 		UpdatedAt:   alert.UpdatedAt,
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"gorm.io/gorm"
+)
+
+type AlertTriggerRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertTriggerRepository(db *gorm.DB) *AlertTriggerRepository {
+	return &AlertTriggerRepository{db: db}
+}
+
+func (r *AlertTriggerRepository) Create(ctx context.Context, trigger *domain.AlertTrigger) error {
+	model := mapAlertTriggerToModel(*trigger)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	trigger.ID = model.ID
+	return nil
+}
+
+// ListByUser returns the latest triggers of a user, newest first. A non-zero
+// alertID narrows the list to that alert.
+func (r *AlertTriggerRepository) ListByUser(ctx context.Context, userID uint, alertID uint, limit int) ([]domain.AlertTrigger, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if alertID != 0 {
+		query = query.Where("alert_id = ?", alertID)
+	}
+	var models []alertTriggerModel
+	if err := query.Order("triggered_at DESC, id DESC").Limit(limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	triggers := make([]domain.AlertTrigger, 0, len(models))
+	for _, model := range models {
+		triggers = append(triggers, domain.AlertTrigger{
+			ID:          model.ID,
+			UserID:      model.UserID,
+			AlertID:     model.AlertID,
+			MarketSlug:  model.MarketSlug,
+			Outcome:     model.Outcome,
+			AssetID:     model.AssetID,
+			Kind:        model.Kind,
+			Price:       model.Price,
+			BestBid:     model.BestBid,
+			BestAsk:     model.BestAsk,
+			Status:      model.Status,
+			Error:       model.Error,
+			TriggeredAt: model.TriggeredAt,
+		})
+	}
+	return triggers, nil
+}
+
+func mapAlertTriggerToModel(trigger domain.AlertTrigger) alertTriggerModel {
+	return alertTriggerModel{
+		ID:          trigger.ID,
+		UserID:      trigger.UserID,
+		AlertID:     trigger.AlertID,
+		MarketSlug:  trigger.MarketSlug,
+		Outcome:     trigger.Outcome,
+		AssetID:     trigger.AssetID,
+		Kind:        trigger.Kind,
+		Price:       trigger.Price,
+		BestBid:     trigger.BestBid,
+		BestAsk:     trigger.BestAsk,
+		Status:      trigger.Status,
+		Error:       trigger.Error,
+		TriggeredAt: trigger.TriggeredAt,
+	}
+}
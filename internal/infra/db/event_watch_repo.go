@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"gorm.io/gorm"
+)
+
+type EventWatchRepository struct {
+	db *gorm.DB
+}
+
+func NewEventWatchRepository(db *gorm.DB) *EventWatchRepository {
+	return &EventWatchRepository{db: db}
+}
+
+func (r *EventWatchRepository) Create(ctx context.Context, watch *domain.EventWatch) error {
+	model, err := mapEventWatchToModel(*watch)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	watch.ID = model.ID
+	watch.CreatedAt = model.CreatedAt
+	watch.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *EventWatchRepository) ListByUser(ctx context.Context, userID uint) ([]domain.EventWatch, error) {
+	var models []eventWatchModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mapEventWatchesToDomain(models)
+}
+
+func (r *EventWatchRepository) ListAll(ctx context.Context) ([]domain.EventWatch, error) {
+	var models []eventWatchModel
+	if err := r.db.WithContext(ctx).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mapEventWatchesToDomain(models)
+}
+
+func (r *EventWatchRepository) UpdateMarkets(ctx context.Context, watchID uint, markets map[string]string) error {
+	encoded, err := json.Marshal(markets)
+	if err != nil {
+		return err
+	}
+	result := r.db.WithContext(ctx).Model(&eventWatchModel{}).Where("id = ?", watchID).Update("markets", string(encoded))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *EventWatchRepository) Delete(ctx context.Context, userID uint, eventSlug string) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND event_slug = ?", userID, eventSlug).Delete(&eventWatchModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func mapEventWatchesToDomain(models []eventWatchModel) ([]domain.EventWatch, error) {
+	watches := make([]domain.EventWatch, 0, len(models))
+	for _, model := range models {
+		markets := make(map[string]string)
+		if model.Markets != "" {
+			if err := json.Unmarshal([]byte(model.Markets), &markets); err != nil {
+				return nil, err
+			}
+		}
+		watches = append(watches, domain.EventWatch{
+			ID:        model.ID,
+			UserID:    model.UserID,
+			EventSlug: model.EventSlug,
+			Markets:   markets,
+			CreatedAt: model.CreatedAt,
+			UpdatedAt: model.UpdatedAt,
+		})
+	}
+	return watches, nil
+}
+
+func mapEventWatchToModel(watch domain.EventWatch) (eventWatchModel, error) {
+	markets := watch.Markets
+	if markets == nil {
+		markets = map[string]string{}
+	}
+	encoded, err := json.Marshal(markets)
+	if err != nil {
+		return eventWatchModel{}, err
+	}
+	return eventWatchModel{
+		ID:        watch.ID,
+		UserID:    watch.UserID,
+		EventSlug: watch.EventSlug,
+		Markets:   string(encoded),
+		CreatedAt: watch.CreatedAt,
+		UpdatedAt: watch.UpdatedAt,
+	}, nil
+}
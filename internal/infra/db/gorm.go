@@ -53,7 +53,7 @@ func Open(cfg config.Config, log *zap.Logger) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
 	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
-	if err := db.AutoMigrate(&userModel{}, &alertModel{}); err != nil {
+	if err := db.AutoMigrate(&userModel{}, &alertModel{}, &alertTriggerModel{}, &eventWatchModel{}, &reminderModel{}, &priceSnapshotModel{}); err != nil {
 		return nil, err
 	}
 
@@ -16,16 +16,79 @@ type userModel struct {
 }
 
 type alertModel struct {
-	ID          uint   `gorm:"primaryKey"`
-	UserID      uint   `gorm:"index:idx_alerts_user_enabled_deleted,priority:1;not null"`
-	MarketSlug  string `gorm:"not null"`
-	ConditionID string `gorm:"not null"`
-	Outcome     string `gorm:"not null"`
-	AssetID     string `gorm:"not null"`
-	Comparator  string `gorm:"not null"`
-	Threshold   string `gorm:"not null"`
-	Enabled     bool   `gorm:"index:idx_alerts_user_enabled_deleted,priority:2"`
+	ID          uint          `gorm:"primaryKey"`
+	UserID      uint          `gorm:"index:idx_alerts_user_enabled_deleted,priority:1;not null"`
+	EventSlug   string        `gorm:"not null;default:''"`
+	EventWide   bool          `gorm:"not null;default:false"`
+	MarketSlug  string        `gorm:"not null"`
+	ConditionID string        `gorm:"not null"`
+	Outcome     string        `gorm:"not null"`
+	AssetID     string        `gorm:"not null"`
+	Kind        string        `gorm:"not null;default:'price'"`
+	Comparator  string        `gorm:"not null"`
+	Threshold   string        `gorm:"not null"`
+	PriceSource string        `gorm:"not null;default:'auto'"`
+	Direction   string        `gorm:"not null;default:''"`
+	Window      time.Duration `gorm:"not null;default:0"`
+	Relative    bool          `gorm:"not null;default:false"`
+	BookSide    string        `gorm:"not null;default:''"`
+	DepthRange  string        `gorm:"not null;default:''"`
+	OneShot     bool          `gorm:"not null;default:false"`
+	Cooldown    time.Duration `gorm:"not null;default:0"`
+	Hysteresis  string        `gorm:"not null;default:'0'"`
+	Rearm       bool          `gorm:"not null;default:false"`
+	Trigger     string        `gorm:"not null;default:'level'"`
+	LastSide    string        `gorm:"not null;default:''"`
+	LastFiredAt *time.Time
+	EndDate     *time.Time
+	Enabled     bool `gorm:"index:idx_alerts_user_enabled_deleted,priority:2"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index:idx_alerts_user_enabled_deleted,priority:3"`
 }
+
+type alertTriggerModel struct {
+	ID          uint      `gorm:"primaryKey"`
+	UserID      uint      `gorm:"index:idx_alert_triggers_user,priority:1;not null"`
+	AlertID     uint      `gorm:"index;not null"`
+	MarketSlug  string    `gorm:"not null"`
+	Outcome     string    `gorm:"not null"`
+	AssetID     string    `gorm:"not null"`
+	Kind        string    `gorm:"not null"`
+	Price       string    `gorm:"not null"`
+	BestBid     string    `gorm:"not null;default:''"`
+	BestAsk     string    `gorm:"not null;default:''"`
+	Status      string    `gorm:"not null"`
+	Error       string    `gorm:"not null;default:''"`
+	TriggeredAt time.Time `gorm:"index:idx_alert_triggers_user,priority:2;not null"`
+}
+
+type priceSnapshotModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	AssetID   string    `gorm:"index:idx_price_snapshots_asset_time,priority:1;not null"`
+	BestBid   string    `gorm:"not null;default:''"`
+	BestAsk   string    `gorm:"not null;default:''"`
+	LastTrade string    `gorm:"not null;default:''"`
+	TakenAt   time.Time `gorm:"index:idx_price_snapshots_asset_time,priority:2;index;not null"`
+}
+
+type eventWatchModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"uniqueIndex:idx_event_watch_user_slug,priority:1;not null"`
+	EventSlug string `gorm:"uniqueIndex:idx_event_watch_user_slug,priority:2;not null"`
+	Markets   string `gorm:"not null;default:'{}'"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type reminderModel struct {
+	ID         uint          `gorm:"primaryKey"`
+	UserID     uint          `gorm:"index;not null"`
+	AlertID    uint          `gorm:"not null"`
+	MarketSlug string        `gorm:"not null"`
+	EndDate    time.Time     `gorm:"not null"`
+	Before     time.Duration `gorm:"not null"`
+	RemindAt   time.Time     `gorm:"index:idx_reminders_due,priority:2;not null"`
+	SentAt     *time.Time    `gorm:"index:idx_reminders_due,priority:1"`
+	CreatedAt  time.Time
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const snapshotBatchSize = 500
+
+type PriceSnapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceSnapshotRepository(db *gorm.DB) *PriceSnapshotRepository {
+	return &PriceSnapshotRepository{db: db}
+}
+
+func (r *PriceSnapshotRepository) CreateBatch(ctx context.Context, snapshots []domain.PriceSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	models := make([]priceSnapshotModel, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		models = append(models, priceSnapshotModel{
+			AssetID:   snapshot.AssetID,
+			BestBid:   formatOptionalDecimal(snapshot.BestBid),
+			BestAsk:   formatOptionalDecimal(snapshot.BestAsk),
+			LastTrade: formatOptionalDecimal(snapshot.LastTrade),
+			TakenAt:   snapshot.TakenAt,
+		})
+	}
+	return r.db.WithContext(ctx).CreateInBatches(&models, snapshotBatchSize).Error
+}
+
+// LatestBefore returns the last snapshot of an asset taken at or before at.
+func (r *PriceSnapshotRepository) LatestBefore(ctx context.Context, assetID string, at time.Time) (*domain.PriceSnapshot, error) {
+	var model priceSnapshotModel
+	err := r.db.WithContext(ctx).
+		Where("asset_id = ? AND taken_at <= ?", assetID, at).
+		Order("taken_at DESC").
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	snapshot := mapPriceSnapshotToDomain(model)
+	return &snapshot, nil
+}
+
+// ListRange returns the snapshots of an asset taken in [from, to], oldest
+// first.
+func (r *PriceSnapshotRepository) ListRange(ctx context.Context, assetID string, from, to time.Time) ([]domain.PriceSnapshot, error) {
+	var models []priceSnapshotModel
+	if err := r.db.WithContext(ctx).
+		Where("asset_id = ? AND taken_at >= ? AND taken_at <= ?", assetID, from, to).
+		Order("taken_at").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	snapshots := make([]domain.PriceSnapshot, 0, len(models))
+	for _, model := range models {
+		snapshots = append(snapshots, mapPriceSnapshotToDomain(model))
+	}
+	return snapshots, nil
+}
+
+func (r *PriceSnapshotRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("taken_at < ?", before).Delete(&priceSnapshotModel{})
+	return result.RowsAffected, result.Error
+}
+
+func mapPriceSnapshotToDomain(model priceSnapshotModel) domain.PriceSnapshot {
+	return domain.PriceSnapshot{
+		ID:        model.ID,
+		AssetID:   model.AssetID,
+		BestBid:   parseOptionalDecimal(model.BestBid),
+		BestAsk:   parseOptionalDecimal(model.BestAsk),
+		LastTrade: parseOptionalDecimal(model.LastTrade),
+		TakenAt:   model.TakenAt,
+	}
+}
+
+func formatOptionalDecimal(value *decimal.Decimal) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+func parseOptionalDecimal(value string) *decimal.Decimal {
+	if value == "" {
+		return nil
+	}
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+func (r *ReminderRepository) Create(ctx context.Context, reminder *domain.Reminder) error {
+	model := mapReminderToModel(*reminder)
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return err
+	}
+	reminder.ID = model.ID
+	reminder.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *ReminderRepository) ListByUser(ctx context.Context, userID uint) ([]domain.Reminder, error) {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND sent_at IS NULL", userID).Order("remind_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mapRemindersToDomain(models), nil
+}
+
+func (r *ReminderRepository) ListDue(ctx context.Context, now time.Time) ([]domain.Reminder, error) {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).Where("sent_at IS NULL AND remind_at <= ?", now).Order("remind_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return mapRemindersToDomain(models), nil
+}
+
+// Reschedule moves the pending reminders of an alert to a new end date,
+// keeping each one's offset before it.
+func (r *ReminderRepository) Reschedule(ctx context.Context, alertID uint, endDate time.Time) error {
+	var models []reminderModel
+	if err := r.db.WithContext(ctx).Where("alert_id = ? AND sent_at IS NULL AND end_date <> ?", alertID, endDate).Find(&models).Error; err != nil {
+		return err
+	}
+	for _, model := range models {
+		if err := r.db.WithContext(ctx).Model(&reminderModel{}).Where("id = ?", model.ID).Updates(map[string]any{
+			"end_date":  endDate,
+			"remind_at": endDate.Add(-model.Before),
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReminderRepository) MarkSent(ctx context.Context, reminderID uint, sentAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&reminderModel{}).Where("id = ?", reminderID).Update("sent_at", sentAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ReminderRepository) Delete(ctx context.Context, userID uint, reminderID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", reminderID, userID).Delete(&reminderModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func mapRemindersToDomain(models []reminderModel) []domain.Reminder {
+	reminders := make([]domain.Reminder, 0, len(models))
+	for _, model := range models {
+		reminders = append(reminders, domain.Reminder{
+			ID:         model.ID,
+			UserID:     model.UserID,
+			AlertID:    model.AlertID,
+			MarketSlug: model.MarketSlug,
+			EndDate:    model.EndDate,
+			Before:     model.Before,
+			RemindAt:   model.RemindAt,
+			SentAt:     model.SentAt,
+			CreatedAt:  model.CreatedAt,
+		})
+	}
+	return reminders
+}
+
+func mapReminderToModel(reminder domain.Reminder) reminderModel {
+	return reminderModel{
+		ID:         reminder.ID,
+		UserID:     reminder.UserID,
+		AlertID:    reminder.AlertID,
+		MarketSlug: reminder.MarketSlug,
+		EndDate:    reminder.EndDate,
+		Before:     reminder.Before,
+		RemindAt:   reminder.RemindAt,
+		SentAt:     reminder.SentAt,
+		CreatedAt:  reminder.CreatedAt,
+	}
+}
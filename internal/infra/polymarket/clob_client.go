@@ -0,0 +1,142 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type CLOBClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+func NewCLOBClient(baseURL string, timeout time.Duration, logger *zap.Logger) *CLOBClient {
+	return &CLOBClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+		logger:  logger,
+	}
+}
+
+// GetPrice returns the best price on the given side (BUY or SELL) of a
+// token's book.
+func (c *CLOBClient) GetPrice(ctx context.Context, tokenID, side string) (decimal.Decimal, error) {
+	params := url.Values{}
+	params.Set("token_id", tokenID)
+	params.Set("side", strings.ToUpper(side))
+	var payload clobPriceResponse
+	if err := c.get(ctx, tokenID, "/price", params, &payload); err != nil {
+		return decimal.Zero, err
+	}
+	return requireDecimal(payload.Price, "price")
+}
+
+func (c *CLOBClient) GetMidpoint(ctx context.Context, tokenID string) (decimal.Decimal, error) {
+	var payload clobMidpointResponse
+	if err := c.get(ctx, tokenID, "/midpoint", tokenParams(tokenID), &payload); err != nil {
+		return decimal.Zero, err
+	}
+	return requireDecimal(payload.Mid, "midpoint")
+}
+
+func (c *CLOBClient) GetSpread(ctx context.Context, tokenID string) (decimal.Decimal, error) {
+	var payload clobSpreadResponse
+	if err := c.get(ctx, tokenID, "/spread", tokenParams(tokenID), &payload); err != nil {
+		return decimal.Zero, err
+	}
+	return requireDecimal(payload.Spread, "spread")
+}
+
+func (c *CLOBClient) GetOrderBook(ctx context.Context, tokenID string) (*domain.OrderBook, error) {
+	var payload clobBookResponse
+	if err := c.get(ctx, tokenID, "/book", tokenParams(tokenID), &payload); err != nil {
+		return nil, err
+	}
+	return &domain.OrderBook{
+		AssetID:   tokenID,
+		Bids:      mapOrderLevels(payload.Bids),
+		Asks:      mapOrderLevels(payload.Asks),
+		Timestamp: parseMillis(payload.Timestamp),
+	}, nil
+}
+
+// GetPriceHistory returns the token's price between from and to, one point
+// per fidelity (rounded to whole minutes, as the API expects).
+func (c *CLOBClient) GetPriceHistory(ctx context.Context, tokenID string, from, to time.Time, fidelity time.Duration) ([]domain.PricePoint, error) {
+	params := url.Values{}
+	params.Set("market", tokenID)
+	params.Set("startTs", strconv.FormatInt(from.Unix(), 10))
+	params.Set("endTs", strconv.FormatInt(to.Unix(), 10))
+	params.Set("fidelity", strconv.Itoa(max(int(fidelity/time.Minute), 1)))
+	var payload clobHistoryResponse
+	if err := c.get(ctx, tokenID, "/prices-history", params, &payload); err != nil {
+		return nil, err
+	}
+
+	points := make([]domain.PricePoint, 0, len(payload.History))
+	for _, point := range payload.History {
+		if !point.P.Valid {
+			continue
+		}
+		points = append(points, domain.PricePoint{At: time.Unix(point.T, 0), Price: point.P.Decimal})
+	}
+	return points, nil
+}
+
+func (c *CLOBClient) get(ctx context.Context, tokenID, path string, params url.Values, payload any) error {
+	endpoint := fmt.Sprintf("%s%s?%s", c.baseURL, path, params.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	c.logger.Info("clob request start", zap.String("token_id", tokenID), zap.String("url", endpoint))
+	response, err := c.client.Do(request)
+	if err != nil {
+		c.logger.Error("clob request failed", zap.String("token_id", tokenID), zap.String("url", endpoint), zap.Error(err))
+		return err
+	}
+	defer response.Body.Close()
+
+	c.logger.Info(
+		"clob request complete",
+		zap.String("token_id", tokenID),
+		zap.String("url", endpoint),
+		zap.Int("status", response.StatusCode),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	if response.StatusCode == http.StatusNotFound {
+		return domain.ErrBookNotFound
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("clob error: status %d", response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(payload)
+}
+
+func tokenParams(tokenID string) url.Values {
+	params := url.Values{}
+	params.Set("token_id", tokenID)
+	return params
+}
+
+func requireDecimal(value NullableDecimal, field string) (decimal.Decimal, error) {
+	if !value.Valid {
+		return decimal.Zero, fmt.Errorf("clob error: missing %s", field)
+	}
+	return value.Decimal, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,9 +31,71 @@ func NewGammaClient(baseURL string, timeout time.Duration, logger *zap.Logger) *
 
 func (c *GammaClient) GetEventBySlug(ctx context.Context, slug string) (*domain.EventMarkets, error) {
 	endpoint := fmt.Sprintf("%s/events/slug/%s", c.baseURL, url.PathEscape(slug))
+	var payload gammaEventResponse
+	if err := c.get(ctx, slug, endpoint, domain.ErrEventNotFound, &payload); err != nil {
+		return nil, err
+	}
+
+	event := &domain.EventMarkets{EventSlug: payload.Slug, Title: payload.Title, Markets: make([]domain.MarketInfo, 0, len(payload.Markets))}
+	for _, market := range payload.Markets {
+		event.Markets = append(event.Markets, mapMarket(market))
+	}
+
+	return event, nil
+}
+
+func (c *GammaClient) GetMarketBySlug(ctx context.Context, slug string) (*domain.MarketInfo, error) {
+	endpoint := fmt.Sprintf("%s/markets/slug/%s", c.baseURL, url.PathEscape(slug))
+	var payload gammaMarket
+	if err := c.get(ctx, slug, endpoint, domain.ErrMarketNotFound, &payload); err != nil {
+		return nil, err
+	}
+
+	market := mapMarket(payload)
+	return &market, nil
+}
+
+func (c *GammaClient) SearchEvents(ctx context.Context, query string, page, limit int) (*domain.EventSearchResult, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit_per_type", strconv.Itoa(limit))
+	params.Set("search_tags", "false")
+	params.Set("search_profiles", "false")
+	endpoint := fmt.Sprintf("%s/public-search?%s", c.baseURL, params.Encode())
+
+	var payload gammaSearchResponse
+	if err := c.get(ctx, query, endpoint, domain.ErrEventNotFound, &payload); err != nil {
+		return nil, err
+	}
+
+	result := &domain.EventSearchResult{
+		Events:  make([]domain.EventSummary, 0, len(payload.Events)),
+		Page:    page,
+		HasMore: payload.Pagination.HasMore,
+		Total:   payload.Pagination.TotalResults,
+	}
+	for _, event := range payload.Events {
+		summary := domain.EventSummary{
+			Slug:    event.Slug,
+			Title:   event.Title,
+			Active:  event.Active,
+			Closed:  event.Closed,
+			EndDate: parseEndDate(event.EndDate),
+		}
+		if event.Volume.Valid {
+			volume := event.Volume.Decimal
+			summary.Volume = &volume
+		}
+		result.Events = append(result.Events, summary)
+	}
+	return result, nil
+}
+
+func (c *GammaClient) get(ctx context.Context, slug, endpoint string, notFound error, payload any) error {
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	start := time.Now()
@@ -40,7 +103,7 @@ func (c *GammaClient) GetEventBySlug(ctx context.Context, slug string) (*domain.
 	response, err := c.client.Do(request)
 	if err != nil {
 		c.logger.Error("gamma request failed", zap.String("slug", slug), zap.String("url", endpoint), zap.Error(err))
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
@@ -53,47 +116,54 @@ func (c *GammaClient) GetEventBySlug(ctx context.Context, slug string) (*domain.
 	)
 
 	if response.StatusCode == http.StatusNotFound {
-		return nil, domain.ErrEventNotFound
+		return notFound
 	}
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return nil, fmt.Errorf("gamma error: status %d", response.StatusCode)
+		return fmt.Errorf("gamma error: status %d", response.StatusCode)
 	}
 
-	var payload gammaEventResponse
-	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
-		return nil, err
-	}
+	return json.NewDecoder(response.Body).Decode(payload)
+}
 
-	event := &domain.EventMarkets{EventSlug: payload.Slug, Markets: make([]domain.MarketInfo, 0, len(payload.Markets))}
-	for _, market := range payload.Markets {
-		var bestBid *decimal.Decimal
-		if market.BestBid.Valid {
-			value := market.BestBid.Decimal
-			bestBid = &value
-		}
-		var bestAsk *decimal.Decimal
-		if market.BestAsk.Valid {
-			value := market.BestAsk.Decimal
-			bestAsk = &value
-		}
-		var lastTrade *decimal.Decimal
-		if market.LastTradePrice.Valid {
-			value := market.LastTradePrice.Decimal
-			lastTrade = &value
-		}
+func mapMarket(market gammaMarket) domain.MarketInfo {
+	var bestBid *decimal.Decimal
+	if market.BestBid.Valid {
+		value := market.BestBid.Decimal
+		bestBid = &value
+	}
+	var bestAsk *decimal.Decimal
+	if market.BestAsk.Valid {
+		value := market.BestAsk.Decimal
+		bestAsk = &value
+	}
+	var lastTrade *decimal.Decimal
+	if market.LastTradePrice.Valid {
+		value := market.LastTradePrice.Decimal
+		lastTrade = &value
+	}
+	endDate := parseEndDate(market.EndDate)
 
-		event.Markets = append(event.Markets, domain.MarketInfo{
-			Slug:          market.Slug,
-			ConditionID:   market.ConditionID,
-			Outcomes:      []string(market.Outcomes),
-			ClobTokenIDs:  []string(market.ClobTokenIDs),
-			Question:      market.Question,
-			OutcomePrices: []string(market.OutcomePrices),
-			BestBid:       bestBid,
-			BestAsk:       bestAsk,
-			LastTrade:     lastTrade,
-		})
+	return domain.MarketInfo{
+		Slug:          market.Slug,
+		ConditionID:   market.ConditionID,
+		Outcomes:      []string(market.Outcomes),
+		ClobTokenIDs:  []string(market.ClobTokenIDs),
+		Question:      market.Question,
+		OutcomePrices: []string(market.OutcomePrices),
+		BestBid:       bestBid,
+		BestAsk:       bestAsk,
+		LastTrade:     lastTrade,
+		Active:        market.Active,
+		Closed:        market.Closed,
+		Resolved:      strings.EqualFold(market.UMAResolution, "resolved"),
+		EndDate:       endDate,
 	}
+}
 
-	return event, nil
+func parseEndDate(value string) *time.Time {
+	parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &parsed
 }
@@ -11,9 +11,27 @@ import (
 
 type gammaEventResponse struct {
 	Slug    string        `json:"slug"`
+	Title   string        `json:"title"`
 	Markets []gammaMarket `json:"markets"`
 }
 
+type gammaSearchResponse struct {
+	Events     []gammaEventSummary `json:"events"`
+	Pagination struct {
+		HasMore      bool `json:"hasMore"`
+		TotalResults int  `json:"totalResults"`
+	} `json:"pagination"`
+}
+
+type gammaEventSummary struct {
+	Slug    string          `json:"slug"`
+	Title   string          `json:"title"`
+	Volume  NullableDecimal `json:"volume"`
+	Active  bool            `json:"active"`
+	Closed  bool            `json:"closed"`
+	EndDate string          `json:"endDate"`
+}
+
 type gammaMarket struct {
 	Slug           string          `json:"slug"`
 	ConditionID    string          `json:"conditionId"`
@@ -24,11 +42,26 @@ type gammaMarket struct {
 	BestBid        NullableDecimal `json:"bestBid"`
 	BestAsk        NullableDecimal `json:"bestAsk"`
 	LastTradePrice NullableDecimal `json:"lastTradePrice"`
+	Active         bool            `json:"active"`
+	Closed         bool            `json:"closed"`
+	UMAResolution  string          `json:"umaResolutionStatus"`
+	EndDate        string          `json:"endDate"`
 }
 
 type wsMessage struct {
 	EventType    string          `json:"event_type"`
+	AssetID      string          `json:"asset_id"`
 	PriceChanges []wsPriceChange `json:"price_changes"`
+	Bids         []wsOrderLevel  `json:"bids"`
+	Asks         []wsOrderLevel  `json:"asks"`
+	Buys         []wsOrderLevel  `json:"buys"`
+	Sells        []wsOrderLevel  `json:"sells"`
+	Price        NullableDecimal `json:"price"`
+	Size         NullableDecimal `json:"size"`
+	Side         string          `json:"side"`
+	OldTickSize  NullableDecimal `json:"old_tick_size"`
+	NewTickSize  NullableDecimal `json:"new_tick_size"`
+	Timestamp    string          `json:"timestamp"`
 }
 
 type wsPriceChange struct {
@@ -36,6 +69,39 @@ type wsPriceChange struct {
 	BestBid NullableDecimal `json:"best_bid"`
 	BestAsk NullableDecimal `json:"best_ask"`
 	Price   NullableDecimal `json:"price"`
+	Size    NullableDecimal `json:"size"`
+	Side    string          `json:"side"`
+}
+
+type wsOrderLevel struct {
+	Price NullableDecimal `json:"price"`
+	Size  NullableDecimal `json:"size"`
+}
+
+type clobPriceResponse struct {
+	Price NullableDecimal `json:"price"`
+}
+
+type clobMidpointResponse struct {
+	Mid NullableDecimal `json:"mid"`
+}
+
+type clobSpreadResponse struct {
+	Spread NullableDecimal `json:"spread"`
+}
+
+type clobBookResponse struct {
+	AssetID   string         `json:"asset_id"`
+	Bids      []wsOrderLevel `json:"bids"`
+	Asks      []wsOrderLevel `json:"asks"`
+	Timestamp string         `json:"timestamp"`
+}
+
+type clobHistoryResponse struct {
+	History []struct {
+		T int64           `json:"t"`
+		P NullableDecimal `json:"p"`
+	} `json:"history"`
 }
 
 type NullableDecimal struct {
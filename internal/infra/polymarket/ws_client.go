@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NasaVasa/botty/internal/domain"
 	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -46,22 +49,41 @@ type WSClient struct {
 	conn        *websocket.Conn
 	readTimeout time.Duration
 	logger      *zap.Logger
+	subscribed  bool
 }
 
 func (c *WSClient) Subscribe(ctx context.Context, assetIDs []string) error {
 	payload := map[string]any{
-		"type":       "market",
 		"assets_ids": assetIDs,
 	}
+	if c.subscribed {
+		payload["operation"] = "subscribe"
+	} else {
+		payload["type"] = "market"
+	}
 	c.logger.Info("ws subscribe", zap.Int("asset_count", len(assetIDs)), zap.Strings("asset_ids", assetIDs))
 	if err := c.conn.WriteJSON(payload); err != nil {
 		c.logger.Error("ws subscribe failed", zap.Error(err))
 		return err
 	}
+	c.subscribed = true
+	return nil
+}
+
+func (c *WSClient) Unsubscribe(ctx context.Context, assetIDs []string) error {
+	payload := map[string]any{
+		"assets_ids": assetIDs,
+		"operation":  "unsubscribe",
+	}
+	c.logger.Info("ws unsubscribe", zap.Int("asset_count", len(assetIDs)), zap.Strings("asset_ids", assetIDs))
+	if err := c.conn.WriteJSON(payload); err != nil {
+		c.logger.Error("ws unsubscribe failed", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
-func (c *WSClient) Receive(ctx context.Context) (*domain.PriceChangeMessage, error) {
+func (c *WSClient) Receive(ctx context.Context) ([]domain.MarketEvent, error) {
 	if c.readTimeout > 0 {
 		_ = c.conn.SetReadDeadline(time.Now().Add(c.readTimeout))
 	}
@@ -71,13 +93,13 @@ func (c *WSClient) Receive(ctx context.Context) (*domain.PriceChangeMessage, err
 		return nil, err
 	}
 
-	message, err := c.decodeMessage(data)
+	events, err := c.decodeMessage(data)
 	if err != nil {
 		c.logger.Debug("ws message ignored", zap.Error(err))
 		return nil, nil
 	}
 
-	return message, nil
+	return events, nil
 }
 
 func (c *WSClient) Close() error {
@@ -85,58 +107,131 @@ func (c *WSClient) Close() error {
 	return c.conn.Close()
 }
 
-func (c *WSClient) decodeMessage(data []byte) (*domain.PriceChangeMessage, error) {
+func (c *WSClient) decodeMessage(data []byte) ([]domain.MarketEvent, error) {
 	trimmed := bytes.TrimSpace(data)
 	if len(trimmed) == 0 {
 		return nil, fmt.Errorf("empty message")
 	}
 
+	var payloads []wsMessage
 	if trimmed[0] == '[' {
-		var payloads []wsMessage
 		if err := json.Unmarshal(trimmed, &payloads); err != nil {
 			return nil, fmt.Errorf("decode ws message array: %w", err)
 		}
-		for _, payload := range payloads {
-			if payload.EventType != "price_change" {
-				continue
+	} else {
+		var payload wsMessage
+		if err := json.Unmarshal(trimmed, &payload); err != nil {
+			return nil, fmt.Errorf("decode ws message: %w", err)
+		}
+		payloads = append(payloads, payload)
+	}
+
+	var events []domain.MarketEvent
+	for _, payload := range payloads {
+		switch payload.EventType {
+		case domain.EventTypePriceChange:
+			events = append(events, mapPriceChanges(payload)...)
+		case domain.EventTypeBook:
+			events = append(events, mapBook(payload))
+		case domain.EventTypeLastTrade:
+			if event, ok := mapLastTrade(payload); ok {
+				events = append(events, event)
+			}
+		case domain.EventTypeTickSize:
+			if event, ok := mapTickSizeChange(payload); ok {
+				events = append(events, event)
 			}
-			return mapPriceChange(payload), nil
 		}
-		return nil, nil
 	}
+	return events, nil
+}
 
-	var payload wsMessage
-	if err := json.Unmarshal(trimmed, &payload); err != nil {
-		return nil, fmt.Errorf("decode ws message: %w", err)
+func mapPriceChanges(payload wsMessage) []domain.MarketEvent {
+	events := make([]domain.MarketEvent, 0, len(payload.PriceChanges))
+	for _, change := range payload.PriceChanges {
+		msgChange := domain.PriceChange{AssetID: change.AssetID, Side: strings.ToUpper(change.Side)}
+		msgChange.BestBid = decimalPtr(change.BestBid)
+		msgChange.BestAsk = decimalPtr(change.BestAsk)
+		msgChange.Price = decimalPtr(change.Price)
+		msgChange.Size = decimalPtr(change.Size)
+		events = append(events, domain.MarketEvent{
+			EventType:   payload.EventType,
+			AssetID:     change.AssetID,
+			PriceChange: &msgChange,
+		})
 	}
-	if payload.EventType != "price_change" {
-		return nil, nil
+	return events
+}
+
+func mapBook(payload wsMessage) domain.MarketEvent {
+	bids := payload.Bids
+	if len(bids) == 0 {
+		bids = payload.Buys
+	}
+	asks := payload.Asks
+	if len(asks) == 0 {
+		asks = payload.Sells
 	}
-	return mapPriceChange(payload), nil
+
+	book := &domain.OrderBook{
+		AssetID:   payload.AssetID,
+		Bids:      mapOrderLevels(bids),
+		Asks:      mapOrderLevels(asks),
+		Timestamp: parseMillis(payload.Timestamp),
+	}
+	return domain.MarketEvent{EventType: payload.EventType, AssetID: payload.AssetID, Book: book}
 }
 
-func mapPriceChange(payload wsMessage) *domain.PriceChangeMessage {
-	message := &domain.PriceChangeMessage{
-		EventType:    payload.EventType,
-		PriceChanges: make([]domain.PriceChange, 0, len(payload.PriceChanges)),
+func mapLastTrade(payload wsMessage) (domain.MarketEvent, bool) {
+	if !payload.Price.Valid {
+		return domain.MarketEvent{}, false
+	}
+	trade := &domain.LastTrade{
+		AssetID:   payload.AssetID,
+		Price:     payload.Price.Decimal,
+		Size:      decimalPtr(payload.Size),
+		Side:      strings.ToUpper(payload.Side),
+		Timestamp: parseMillis(payload.Timestamp),
 	}
+	return domain.MarketEvent{EventType: payload.EventType, AssetID: payload.AssetID, LastTrade: trade}, true
+}
 
-	for _, change := range payload.PriceChanges {
-		msgChange := domain.PriceChange{AssetID: change.AssetID}
-		if change.BestBid.Valid {
-			value := change.BestBid.Decimal
-			msgChange.BestBid = &value
-		}
-		if change.BestAsk.Valid {
-			value := change.BestAsk.Decimal
-			msgChange.BestAsk = &value
-		}
-		if change.Price.Valid {
-			value := change.Price.Decimal
-			msgChange.Price = &value
+func mapTickSizeChange(payload wsMessage) (domain.MarketEvent, bool) {
+	if !payload.NewTickSize.Valid {
+		return domain.MarketEvent{}, false
+	}
+	change := &domain.TickSizeChange{
+		AssetID:     payload.AssetID,
+		OldTickSize: payload.OldTickSize.Decimal,
+		NewTickSize: payload.NewTickSize.Decimal,
+		Timestamp:   parseMillis(payload.Timestamp),
+	}
+	return domain.MarketEvent{EventType: payload.EventType, AssetID: payload.AssetID, TickSizeChange: change}, true
+}
+
+func mapOrderLevels(levels []wsOrderLevel) []domain.OrderLevel {
+	mapped := make([]domain.OrderLevel, 0, len(levels))
+	for _, level := range levels {
+		if !level.Price.Valid || !level.Size.Valid {
+			continue
 		}
-		message.PriceChanges = append(message.PriceChanges, msgChange)
+		mapped = append(mapped, domain.OrderLevel{Price: level.Price.Decimal, Size: level.Size.Decimal})
 	}
+	return mapped
+}
 
-	return message
+func decimalPtr(value NullableDecimal) *decimal.Decimal {
+	if !value.Valid {
+		return nil
+	}
+	dec := value.Decimal
+	return &dec
+}
+
+func parseMillis(value string) time.Time {
+	millis, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	return time.UnixMilli(millis)
 }
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/NasaVasa/botty/internal/domain"
+)
+
+const historyLimit = 20
+
+type AlertHistoryUsecase struct {
+	users    domain.UserRepository
+	triggers domain.AlertTriggerRepository
+}
+
+func NewAlertHistoryUsecase(users domain.UserRepository, triggers domain.AlertTriggerRepository) *AlertHistoryUsecase {
+	return &AlertHistoryUsecase{users: users, triggers: triggers}
+}
+
+// ListTriggers returns the latest firings of the user's alerts, newest first.
+// alertID 0 lists all alerts, including ones deleted since.
+func (u *AlertHistoryUsecase) ListTriggers(ctx context.Context, telegramUserID int64, alertID uint) ([]domain.AlertTrigger, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	return u.triggers.ListByUser(ctx, user.ID, alertID, historyLimit)
+}
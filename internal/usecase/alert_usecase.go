@@ -3,11 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NasaVasa/botty/internal/domain"
 	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 )
 
 var (
@@ -18,19 +20,127 @@ var (
 	ErrAlertNotFound     = errors.New("alert not found")
 	ErrEventNotFound     = errors.New("event not found")
 	ErrMarketNotInEvent  = errors.New("market not in event")
+	ErrInvalidCooldown   = errors.New("invalid cooldown")
+	ErrInvalidHysteresis = errors.New("invalid hysteresis")
+	ErrInvalidDirection  = errors.New("invalid direction")
+	ErrInvalidMoveAmount = errors.New("invalid move amount")
+	ErrInvalidWindow     = errors.New("invalid window")
+	ErrCrossUnsupported  = errors.New("crossing trigger not supported")
+	ErrRearmUnsupported  = errors.New("rearm not supported")
+	ErrInvalidBookSide   = errors.New("invalid book side")
+	ErrInvalidDepthRange = errors.New("invalid depth range")
+
+	ErrInvalidPriceSource     = errors.New("invalid price source")
+	ErrPriceSourceUnsupported = errors.New("price source not supported")
+	ErrNoEventMarkets         = errors.New("event has no markets")
+	ErrMarketClosed           = errors.New("market closed")
+	ErrNoOrderBook            = errors.New("market has no order book")
+	ErrEmptyUpdate            = errors.New("empty alert update")
+	ErrFieldNotEditable       = errors.New("field not editable for alert kind")
 )
 
+const maxMoveWindow = 24 * time.Hour
+
+type AlertOptions struct {
+	Crossing    bool
+	OneShot     bool
+	Cooldown    string
+	Rearm       bool
+	Hysteresis  string
+	PriceSource string
+}
+
+// rearm reports whether the alert should fire once and wait for the price to
+// move back before firing again. A rearm delta implies it.
+func (o AlertOptions) rearm() bool {
+	return o.Rearm || strings.TrimSpace(o.Hysteresis) != ""
+}
+
+// AlertUpdate lists the fields /edit may change. Empty fields are left as
+// they are; values use the same syntax as when creating an alert, and a
+// Hysteresis of "off" turns re-arming off.
+type AlertUpdate struct {
+	Comparator  string
+	Threshold   string
+	Cooldown    string
+	Hysteresis  string
+	PriceSource string
+}
+
 type AlertUsecase struct {
 	users  domain.UserRepository
 	alerts domain.AlertRepository
 	gamma  domain.GammaClient
+	clob   domain.CLOBClient
+	logger *zap.Logger
+}
+
+func NewAlertUsecase(users domain.UserRepository, alerts domain.AlertRepository, gamma domain.GammaClient, clob domain.CLOBClient, logger *zap.Logger) *AlertUsecase {
+	return &AlertUsecase{users: users, alerts: alerts, gamma: gamma, clob: clob, logger: logger}
 }
 
-func NewAlertUsecase(users domain.UserRepository, alerts domain.AlertRepository, gamma domain.GammaClient) *AlertUsecase {
-	return &AlertUsecase{users: users, alerts: alerts, gamma: gamma}
+func (u *AlertUsecase) AddAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
+	return u.addThresholdAlert(ctx, domain.AlertKindPrice, telegramUserID, eventSlug, marketSlug, outcome, comparator, threshold, options)
 }
 
-func (u *AlertUsecase) AddAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, comparator, threshold string) (*domain.Alert, error) {
+func (u *AlertUsecase) AddSpreadAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
+	return u.addThresholdAlert(ctx, domain.AlertKindSpread, telegramUserID, eventSlug, marketSlug, outcome, comparator, threshold, options)
+}
+
+func (u *AlertUsecase) AddTradeAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
+	return u.addThresholdAlert(ctx, domain.AlertKindTrade, telegramUserID, eventSlug, marketSlug, outcome, comparator, threshold, options)
+}
+
+func (u *AlertUsecase) AddTickAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome string, options AlertOptions) (*domain.Alert, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	if options.Crossing {
+		return nil, ErrCrossUnsupported
+	}
+
+	if options.rearm() {
+		return nil, ErrRearmUnsupported
+	}
+
+	if options.PriceSource != "" {
+		return nil, ErrPriceSourceUnsupported
+	}
+
+	cooldown, hysteresis, err := parseAlertOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := &domain.Alert{
+		UserID:     user.ID,
+		Kind:       domain.AlertKindTick,
+		Threshold:  decimal.Zero.String(),
+		OneShot:    options.OneShot,
+		Cooldown:   cooldown,
+		Hysteresis: hysteresis.String(),
+		Rearm:      options.rearm(),
+		Trigger:    domain.TriggerLevel,
+		Enabled:    true,
+	}
+
+	if err := u.bindMarket(ctx, alert, eventSlug, marketSlug, outcome); err != nil {
+		return nil, err
+	}
+
+	if err := u.alerts.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (u *AlertUsecase) addThresholdAlert(ctx context.Context, kind string, telegramUserID int64, eventSlug, marketSlug, outcome, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
 	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
 	if err != nil {
 		if err == domain.ErrNotFound {
@@ -48,33 +158,129 @@ func (u *AlertUsecase) AddAlert(ctx context.Context, telegramUserID int64, event
 	if err != nil {
 		return nil, ErrInvalidThreshold
 	}
+	if kind == domain.AlertKindSpread && decThreshold.IsNegative() {
+		return nil, ErrInvalidThreshold
+	}
 
-	event, err := u.gamma.GetEventBySlug(ctx, eventSlug)
+	source := ""
+	if kind == domain.AlertKindPrice {
+		source, err = normalizePriceSource(options.PriceSource)
+		if err != nil {
+			return nil, err
+		}
+	} else if options.PriceSource != "" {
+		return nil, ErrPriceSourceUnsupported
+	}
+
+	cooldown, hysteresis, err := parseAlertOptions(options)
 	if err != nil {
-		if errors.Is(err, domain.ErrEventNotFound) {
-			return nil, ErrEventNotFound
+		return nil, err
+	}
+
+	trigger := domain.TriggerLevel
+	if options.Crossing {
+		trigger = domain.TriggerCross
+	}
+
+	alert := &domain.Alert{
+		UserID:      user.ID,
+		Kind:        kind,
+		Comparator:  normalizedComparator,
+		Threshold:   decThreshold.String(),
+		PriceSource: source,
+		OneShot:     options.OneShot,
+		Cooldown:    cooldown,
+		Hysteresis:  hysteresis.String(),
+		Rearm:       options.rearm(),
+		Trigger:     trigger,
+		Enabled:     true,
+	}
+
+	if err := u.bindMarket(ctx, alert, eventSlug, marketSlug, outcome); err != nil {
+		return nil, err
+	}
+
+	if err := u.alerts.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// AddEventAlert creates a price alert for every market of an event. Outcome
+// "any" watches all outcomes; otherwise it is matched per market, like
+// AddAlert. Markets are expanded by the alerting manager, so markets added to
+// the event later are picked up too.
+func (u *AlertUsecase) AddEventAlert(ctx context.Context, telegramUserID int64, eventSlug, outcome, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
 		}
 		return nil, err
 	}
 
-	selected, ok := findMarketBySlug(marketSlug, event)
-	if !ok {
-		return nil, ErrMarketNotInEvent
+	normalizedComparator, err := normalizeComparator(comparator)
+	if err != nil {
+		return nil, ErrInvalidComparator
 	}
 
-	assetID, normalizedOutcome, err := mapOutcomeToAssetID(selected, outcome)
+	decThreshold, err := decimal.NewFromString(strings.TrimSpace(threshold))
 	if err != nil {
-		return nil, ErrInvalidOutcome
+		return nil, ErrInvalidThreshold
+	}
+
+	source, err := normalizePriceSource(options.PriceSource)
+	if err != nil {
+		return nil, err
+	}
+
+	cooldown, hysteresis, err := parseAlertOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := domain.TriggerLevel
+	if options.Crossing {
+		trigger = domain.TriggerCross
+	}
+
+	event, err := u.gamma.GetEventBySlug(ctx, strings.TrimSpace(eventSlug))
+	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if len(event.Markets) == 0 {
+		return nil, ErrNoEventMarkets
+	}
+	markets := openMarkets(event.Markets, time.Now())
+	if len(markets) == 0 {
+		return nil, ErrMarketClosed
+	}
+
+	normalizedOutcome := strings.TrimSpace(outcome)
+	if strings.EqualFold(normalizedOutcome, eventOutcomeAny) {
+		normalizedOutcome = ""
+	} else if _, err := expandEventMarkets(markets, normalizedOutcome); err != nil {
+		return nil, err
 	}
 
 	alert := &domain.Alert{
 		UserID:      user.ID,
-		MarketSlug:  selected.Slug,
-		ConditionID: selected.ConditionID,
+		EventSlug:   strings.TrimSpace(eventSlug),
+		EventWide:   true,
 		Outcome:     normalizedOutcome,
-		AssetID:     assetID,
+		Kind:        domain.AlertKindPrice,
 		Comparator:  normalizedComparator,
 		Threshold:   decThreshold.String(),
+		PriceSource: source,
+		OneShot:     options.OneShot,
+		Cooldown:    cooldown,
+		Hysteresis:  hysteresis.String(),
+		Rearm:       options.rearm(),
+		Trigger:     trigger,
 		Enabled:     true,
 	}
 
@@ -85,6 +291,177 @@ func (u *AlertUsecase) AddAlert(ctx context.Context, telegramUserID int64, event
 	return alert, nil
 }
 
+func (u *AlertUsecase) AddMoveAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, direction, amount, window string, options AlertOptions) (*domain.Alert, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	normalizedDirection, err := normalizeDirection(direction)
+	if err != nil {
+		return nil, err
+	}
+
+	moveAmount, relative, err := parseMoveAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	moveWindow, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || moveWindow <= 0 || moveWindow > maxMoveWindow {
+		return nil, ErrInvalidWindow
+	}
+
+	if options.Crossing {
+		return nil, ErrCrossUnsupported
+	}
+
+	if options.PriceSource != "" {
+		return nil, ErrPriceSourceUnsupported
+	}
+
+	cooldown, hysteresis, err := parseAlertOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := &domain.Alert{
+		UserID:     user.ID,
+		Kind:       domain.AlertKindMove,
+		Comparator: ">=",
+		Threshold:  moveAmount.String(),
+		Direction:  normalizedDirection,
+		Window:     moveWindow,
+		Relative:   relative,
+		OneShot:    options.OneShot,
+		Cooldown:   cooldown,
+		Hysteresis: hysteresis.String(),
+		Rearm:      options.rearm(),
+		Trigger:    domain.TriggerLevel,
+		Enabled:    true,
+	}
+
+	if err := u.bindMarket(ctx, alert, eventSlug, marketSlug, outcome); err != nil {
+		return nil, err
+	}
+
+	if err := u.alerts.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (u *AlertUsecase) AddDepthAlert(ctx context.Context, telegramUserID int64, eventSlug, marketSlug, outcome, side, depthRange, comparator, threshold string, options AlertOptions) (*domain.Alert, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	normalizedSide, err := normalizeBookSide(side)
+	if err != nil {
+		return nil, err
+	}
+
+	decRange, err := parsePriceDelta(depthRange)
+	if err != nil || decRange.IsNegative() {
+		return nil, ErrInvalidDepthRange
+	}
+
+	normalizedComparator, err := normalizeComparator(comparator)
+	if err != nil {
+		return nil, ErrInvalidComparator
+	}
+
+	notional, err := parseNotional(threshold)
+	if err != nil {
+		return nil, ErrInvalidThreshold
+	}
+
+	if options.PriceSource != "" {
+		return nil, ErrPriceSourceUnsupported
+	}
+
+	cooldown, hysteresis, err := parseAlertOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := domain.TriggerLevel
+	if options.Crossing {
+		trigger = domain.TriggerCross
+	}
+
+	alert := &domain.Alert{
+		UserID:     user.ID,
+		Kind:       domain.AlertKindDepth,
+		Comparator: normalizedComparator,
+		Threshold:  notional.String(),
+		BookSide:   normalizedSide,
+		DepthRange: decRange.String(),
+		OneShot:    options.OneShot,
+		Cooldown:   cooldown,
+		Hysteresis: hysteresis.String(),
+		Rearm:      options.rearm(),
+		Trigger:    trigger,
+		Enabled:    true,
+	}
+
+	if err := u.bindMarket(ctx, alert, eventSlug, marketSlug, outcome); err != nil {
+		return nil, err
+	}
+
+	if err := u.alerts.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (u *AlertUsecase) bindMarket(ctx context.Context, alert *domain.Alert, eventSlug, marketSlug, outcome string) error {
+	event, err := u.gamma.GetEventBySlug(ctx, eventSlug)
+	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return ErrEventNotFound
+		}
+		return err
+	}
+
+	selected, ok := findMarketBySlug(marketSlug, event)
+	if !ok {
+		return ErrMarketNotInEvent
+	}
+	if marketFinished(selected, time.Now()) {
+		return ErrMarketClosed
+	}
+
+	assetID, normalizedOutcome, err := mapOutcomeToAssetID(selected, outcome)
+	if err != nil {
+		return err
+	}
+	// Gamma can list a market whose token never trades; the CLOB knows. Other
+	// CLOB failures do not block creation, Gamma has vouched for the market.
+	if _, err := u.clob.GetOrderBook(ctx, assetID); errors.Is(err, domain.ErrBookNotFound) {
+		return ErrNoOrderBook
+	} else if err != nil {
+		u.logger.Warn("failed to check order book, creating alert anyway", zap.String("asset_id", assetID), zap.Error(err))
+	}
+
+	alert.EventSlug = eventSlug
+	alert.MarketSlug = selected.Slug
+	alert.ConditionID = selected.ConditionID
+	alert.Outcome = normalizedOutcome
+	alert.AssetID = assetID
+	alert.EndDate = selected.EndDate
+	return nil
+}
+
 func (u *AlertUsecase) ListAlerts(ctx context.Context, telegramUserID int64) ([]domain.Alert, error) {
 	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
 	if err != nil {
@@ -124,6 +501,128 @@ func (u *AlertUsecase) DeleteAlert(ctx context.Context, telegramUserID int64, al
 	return nil
 }
 
+// UpdateAlert changes an alert in place, keeping its ID and market. A new
+// comparator or threshold also resets the stored crossing side, so the alert
+// is evaluated afresh once the alerting manager resyncs the user.
+func (u *AlertUsecase) UpdateAlert(ctx context.Context, telegramUserID int64, alertID uint, update AlertUpdate) (*domain.Alert, error) {
+	if update == (AlertUpdate{}) {
+		return nil, ErrEmptyUpdate
+	}
+
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	alerts, err := u.alerts.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var alert *domain.Alert
+	for i := range alerts {
+		if alerts[i].ID == alertID {
+			alert = &alerts[i]
+			break
+		}
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+
+	if err := applyAlertUpdate(alert, update); err != nil {
+		return nil, err
+	}
+
+	if err := u.alerts.Update(ctx, alert); err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func applyAlertUpdate(alert *domain.Alert, update AlertUpdate) error {
+	if update.Comparator != "" {
+		if alert.Kind == domain.AlertKindMove || alert.Kind == domain.AlertKindTick {
+			return ErrFieldNotEditable
+		}
+		comparator, err := normalizeComparator(update.Comparator)
+		if err != nil {
+			return ErrInvalidComparator
+		}
+		alert.Comparator = comparator
+		alert.LastSide = ""
+	}
+
+	if update.Threshold != "" {
+		switch alert.Kind {
+		case domain.AlertKindTick:
+			return ErrFieldNotEditable
+		case domain.AlertKindMove:
+			amount, relative, err := parseMoveAmount(update.Threshold)
+			if err != nil {
+				return err
+			}
+			alert.Threshold = amount.String()
+			alert.Relative = relative
+		case domain.AlertKindDepth:
+			notional, err := parseNotional(update.Threshold)
+			if err != nil {
+				return ErrInvalidThreshold
+			}
+			alert.Threshold = notional.String()
+		default:
+			threshold, err := decimal.NewFromString(strings.TrimSpace(update.Threshold))
+			if err != nil || (alert.Kind == domain.AlertKindSpread && threshold.IsNegative()) {
+				return ErrInvalidThreshold
+			}
+			alert.Threshold = threshold.String()
+		}
+		alert.LastSide = ""
+	}
+
+	if update.PriceSource != "" {
+		if alert.Kind != domain.AlertKindPrice {
+			return ErrPriceSourceUnsupported
+		}
+		source, err := normalizePriceSource(update.PriceSource)
+		if err != nil {
+			return err
+		}
+		alert.PriceSource = source
+	}
+
+	if update.Hysteresis != "" && alert.Kind == domain.AlertKindTick {
+		return ErrRearmUnsupported
+	}
+	if strings.EqualFold(strings.TrimSpace(update.Hysteresis), "off") {
+		update.Hysteresis = ""
+		alert.Hysteresis = decimal.Zero.String()
+		alert.Rearm = false
+		alert.LastSide = ""
+	}
+	if update.Cooldown != "" || update.Hysteresis != "" {
+		cooldown, hysteresis, err := parseAlertOptions(AlertOptions{Cooldown: update.Cooldown, Hysteresis: update.Hysteresis})
+		if err != nil {
+			return err
+		}
+		if update.Cooldown != "" {
+			alert.Cooldown = cooldown
+		}
+		if update.Hysteresis != "" {
+			alert.Hysteresis = hysteresis.String()
+			alert.Rearm = true
+			alert.LastSide = ""
+		}
+	}
+	return nil
+}
+
 func (u *AlertUsecase) setEnabled(ctx context.Context, telegramUserID int64, alertID uint, enabled bool) error {
 	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
 	if err != nil {
@@ -154,37 +653,127 @@ func normalizeComparator(input string) (string, error) {
 	}
 }
 
-func findMarketBySlug(marketSlug string, event *domain.EventMarkets) (domain.MarketInfo, bool) {
-	for _, market := range event.Markets {
-		if market.Slug == marketSlug {
-			return market, true
+func normalizePriceSource(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "auto":
+		return domain.PriceSourceAuto, nil
+	case "bid":
+		return domain.PriceSourceBid, nil
+	case "ask":
+		return domain.PriceSourceAsk, nil
+	case "mid":
+		return domain.PriceSourceMid, nil
+	case "last":
+		return domain.PriceSourceLast, nil
+	default:
+		return "", ErrInvalidPriceSource
+	}
+}
+
+func normalizeDirection(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "up", "+":
+		return domain.DirectionUp, nil
+	case "down", "-":
+		return domain.DirectionDown, nil
+	case "any", "both", "+-":
+		return domain.DirectionAny, nil
+	default:
+		return "", ErrInvalidDirection
+	}
+}
+
+func normalizeBookSide(input string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "bid", "bids", "buy":
+		return domain.BookSideBid, nil
+	case "ask", "asks", "sell":
+		return domain.BookSideAsk, nil
+	default:
+		return "", ErrInvalidBookSide
+	}
+}
+
+func parsePriceDelta(input string) (decimal.Decimal, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if strings.HasSuffix(value, "c") {
+		cents, err := decimal.NewFromString(strings.TrimSuffix(value, "c"))
+		if err != nil {
+			return decimal.Zero, err
 		}
+		return cents.Mul(decimal.New(1, -2)), nil
 	}
-	return domain.MarketInfo{}, false
+	return decimal.NewFromString(value)
 }
 
-func mapOutcomeToAssetID(market domain.MarketInfo, outcome string) (string, string, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(outcome))
-	if normalized != "YES" && normalized != "NO" {
-		return "", "", fmt.Errorf("invalid outcome")
+func parseNotional(input string) (decimal.Decimal, error) {
+	value := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(input), "$"))
+	scale := decimal.NewFromInt(1)
+	if strings.HasSuffix(value, "k") {
+		value = strings.TrimSuffix(value, "k")
+		scale = decimal.NewFromInt(1000)
 	}
-	if len(market.ClobTokenIDs) < 2 {
-		return "", "", fmt.Errorf("missing token ids")
+	amount, err := decimal.NewFromString(value)
+	if err != nil || amount.IsNegative() {
+		return decimal.Zero, ErrInvalidThreshold
 	}
+	return amount.Mul(scale), nil
+}
 
-	if len(market.Outcomes) >= 2 {
-		first := strings.ToUpper(market.Outcomes[0])
-		second := strings.ToUpper(market.Outcomes[1])
-		if first == "YES" && second == "NO" {
-			if normalized == "YES" {
-				return market.ClobTokenIDs[0], normalized, nil
-			}
-			return market.ClobTokenIDs[1], normalized, nil
+func parseMoveAmount(input string) (decimal.Decimal, bool, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	relative := false
+	scale := decimal.NewFromInt(1)
+	switch {
+	case strings.HasSuffix(value, "%"):
+		value = strings.TrimSuffix(value, "%")
+		relative = true
+	case strings.HasSuffix(value, "c"):
+		value = strings.TrimSuffix(value, "c")
+		scale = decimal.New(1, -2)
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil || !amount.IsPositive() {
+		return decimal.Zero, false, ErrInvalidMoveAmount
+	}
+	return amount.Mul(scale), relative, nil
+}
+
+func parseAlertOptions(options AlertOptions) (time.Duration, decimal.Decimal, error) {
+	var cooldown time.Duration
+	if value := strings.TrimSpace(options.Cooldown); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return 0, decimal.Zero, ErrInvalidCooldown
+		}
+		cooldown = parsed
+	}
+
+	hysteresis := decimal.Zero
+	if value := strings.TrimSpace(options.Hysteresis); value != "" {
+		parsed, err := decimal.NewFromString(value)
+		if err != nil || parsed.IsNegative() {
+			return 0, decimal.Zero, ErrInvalidHysteresis
 		}
+		hysteresis = parsed
 	}
 
-	if normalized == "YES" {
-		return market.ClobTokenIDs[0], normalized, nil
+	return cooldown, hysteresis, nil
+}
+
+// findMarketBySlug also accepts the 1-based index shown by /event, so users
+// can pick a market without copying its slug.
+func findMarketBySlug(marketSlug string, event *domain.EventMarkets) (domain.MarketInfo, bool) {
+	marketSlug = strings.TrimSpace(marketSlug)
+	for _, market := range event.Markets {
+		if market.Slug == marketSlug {
+			return market, true
+		}
+	}
+	index, err := strconv.Atoi(marketSlug)
+	if err != nil || index < 1 || index > len(event.Markets) {
+		return domain.MarketInfo{}, false
 	}
-	return market.ClobTokenIDs[1], normalized, nil
+	return event.Markets[index-1], true
 }
@@ -16,33 +16,60 @@ type Notifier interface {
 }
 
 type AlertingManager struct {
-	users     domain.UserRepository
-	alerts    domain.AlertRepository
-	wsFactory domain.MarketWSFactory
-	notifier  Notifier
-	logger    *zap.Logger
-
-	mu      sync.Mutex
-	runners map[int64]*userRunner
+	users        domain.UserRepository
+	alerts       domain.AlertRepository
+	triggers     domain.AlertTriggerRepository
+	reminders    domain.ReminderRepository
+	gamma        domain.GammaClient
+	clob         domain.CLOBClient
+	hub          *MarketHub
+	notifier     Notifier
+	logger       *zap.Logger
+	windows      *priceWindows
+	books        *orderBooks
+	events       *EventCache
+	eventRefresh time.Duration
+	marketCheck  time.Duration
+
+	syncMu     sync.Mutex
+	mu         sync.Mutex
+	watches    map[int64]*userWatch
+	eventUsers map[int64]eventUser
 }
 
-type userRunner struct {
-	cancel context.CancelFunc
-	done   chan struct{}
+type eventUser struct {
+	user  *domain.User
+	slugs map[string]struct{}
 }
 
-func NewAlertingManager(users domain.UserRepository, alerts domain.AlertRepository, wsFactory domain.MarketWSFactory, notifier Notifier, logger *zap.Logger) *AlertingManager {
-	return &AlertingManager{
-		users:     users,
-		alerts:    alerts,
-		wsFactory: wsFactory,
-		notifier:  notifier,
-		logger:    logger,
-		runners:   make(map[int64]*userRunner),
+func NewAlertingManager(users domain.UserRepository, alerts domain.AlertRepository, triggers domain.AlertTriggerRepository, reminders domain.ReminderRepository, gamma domain.GammaClient, clob domain.CLOBClient, hub *MarketHub, events *EventCache, notifier Notifier, eventRefresh, marketCheck time.Duration, logger *zap.Logger) *AlertingManager {
+	m := &AlertingManager{
+		users:        users,
+		alerts:       alerts,
+		triggers:     triggers,
+		reminders:    reminders,
+		gamma:        gamma,
+		clob:         clob,
+		hub:          hub,
+		notifier:     notifier,
+		logger:       logger,
+		windows:      newPriceWindows(),
+		books:        newOrderBooks(),
+		events:       events,
+		eventRefresh: eventRefresh,
+		marketCheck:  marketCheck,
+		watches:      make(map[int64]*userWatch),
+		eventUsers:   make(map[int64]eventUser),
 	}
+	hub.AddObserver(m.windows)
+	hub.AddObserver(m.books)
+	return m
 }
 
 func (m *AlertingManager) StartAll(ctx context.Context) error {
+	go m.runEventRefresh(ctx)
+	go m.runMarketCheck(ctx)
+
 	userIDs, err := m.alerts.ListUserIDsWithEnabledAlerts(ctx)
 	if err != nil {
 		return err
@@ -56,13 +83,12 @@ func (m *AlertingManager) StartAll(ctx context.Context) error {
 			m.logger.Warn("failed to load user for alerting", zap.Uint("user_id", userID), zap.Error(err))
 			continue
 		}
-		m.startUser(ctx, user)
+		m.syncUser(ctx, user)
 	}
 	return nil
 }
 
-func (m *AlertingManager) RestartUser(ctx context.Context, telegramUserID int64) {
-	m.StopUser(telegramUserID)
+func (m *AlertingManager) SyncUser(ctx context.Context, telegramUserID int64) {
 	user, err := m.users.GetByTelegramID(ctx, telegramUserID)
 	if err != nil {
 		if err != domain.ErrNotFound {
@@ -70,33 +96,34 @@ func (m *AlertingManager) RestartUser(ctx context.Context, telegramUserID int64)
 		}
 		return
 	}
-	m.startUser(ctx, user)
+	m.syncUser(ctx, user)
 }
 
 func (m *AlertingManager) StopUser(telegramUserID int64) {
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
 	m.mu.Lock()
-	runner, ok := m.runners[telegramUserID]
+	watch, ok := m.watches[telegramUserID]
 	if ok {
-		delete(m.runners, telegramUserID)
+		delete(m.watches, telegramUserID)
 	}
+	delete(m.eventUsers, telegramUserID)
 	m.mu.Unlock()
 
 	if !ok {
 		return
 	}
 
-	runner.cancel()
-	select {
-	case <-runner.done:
-	case <-time.After(5 * time.Second):
-		m.logger.Warn("timeout stopping alerting runner", zap.Int64("telegram_user_id", telegramUserID))
-	}
+	m.hub.Unsubscribe(context.Background(), watch, watch.assetIDs())
+	watch.close()
+	m.refreshMarketState()
 }
 
 func (m *AlertingManager) StopAll() {
 	m.mu.Lock()
-	ids := make([]int64, 0, len(m.runners))
-	for id := range m.runners {
+	ids := make([]int64, 0, len(m.watches))
+	for id := range m.watches {
 		ids = append(ids, id)
 	}
 	m.mu.Unlock()
@@ -106,152 +133,421 @@ func (m *AlertingManager) StopAll() {
 	}
 }
 
-func (m *AlertingManager) startUser(ctx context.Context, user *domain.User) {
+func (m *AlertingManager) syncUser(ctx context.Context, user *domain.User) {
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
 	alerts, err := m.alerts.ListEnabledByUser(ctx, user.ID)
 	if err != nil {
 		m.logger.Warn("failed to load alerts", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
 		return
 	}
-	if len(alerts) == 0 {
-		return
+	assetAlerts := m.buildAssetAlerts(ctx, alerts)
+
+	slugs := make(map[string]struct{})
+	for _, alert := range alerts {
+		if alert.EventWide {
+			slugs[alert.EventSlug] = struct{}{}
+		}
 	}
 
 	m.mu.Lock()
-	if existing, ok := m.runners[user.TelegramUserID]; ok {
-		m.mu.Unlock()
-		m.logger.Debug("alerting runner already active", zap.Int64("telegram_user_id", user.TelegramUserID))
-		existing.cancel()
-		<-existing.done
+	if len(slugs) > 0 {
+		m.eventUsers[user.TelegramUserID] = eventUser{user: user, slugs: slugs}
 	} else {
+		delete(m.eventUsers, user.TelegramUserID)
+	}
+	watch, ok := m.watches[user.TelegramUserID]
+	switch {
+	case !ok && len(assetAlerts) == 0:
 		m.mu.Unlock()
+		return
+	case !ok:
+		watch = newUserWatch(m, user)
+		m.watches[user.TelegramUserID] = watch
+	case len(assetAlerts) == 0:
+		delete(m.watches, user.TelegramUserID)
 	}
+	m.mu.Unlock()
 
-	childCtx, cancel := context.WithCancel(ctx)
-	runner := &userRunner{cancel: cancel, done: make(chan struct{})}
+	added, removed := watch.replace(assetAlerts)
+	m.refreshMarketState()
+	if len(added) > 0 {
+		m.hub.Subscribe(ctx, watch, added)
+	}
+	if len(removed) > 0 {
+		m.hub.Unsubscribe(ctx, watch, removed)
+	}
+	if len(assetAlerts) == 0 {
+		watch.close()
+	}
+	m.logger.Debug(
+		"alerting watch synced",
+		zap.Int64("telegram_user_id", user.TelegramUserID),
+		zap.Int("added_assets", len(added)),
+		zap.Int("removed_assets", len(removed)),
+	)
+}
 
+func (m *AlertingManager) refreshMarketState() {
 	m.mu.Lock()
-	m.runners[user.TelegramUserID] = runner
+	watches := make([]*userWatch, 0, len(m.watches))
+	for _, watch := range m.watches {
+		watches = append(watches, watch)
+	}
 	m.mu.Unlock()
 
-	go func() {
-		defer close(runner.done)
-		m.runUser(childCtx, user, alerts)
-	}()
+	spans := make(map[string]time.Duration)
+	books := make(map[string]struct{})
+	for _, watch := range watches {
+		watch.collectMarketState(spans, books)
+	}
+	m.windows.setSpans(spans)
+	if added := m.books.setTracked(books); len(added) > 0 {
+		go m.seedBooks(added)
+	}
+}
+
+// seedBooks fetches the current book of newly tracked assets, so depth
+// alerts need not wait for the next book event.
+func (m *AlertingManager) seedBooks(assetIDs []string) {
+	ctx := context.Background()
+	for _, assetID := range assetIDs {
+		book, err := m.clob.GetOrderBook(ctx, assetID)
+		if err != nil {
+			m.logger.Warn("failed to seed order book", zap.String("asset_id", assetID), zap.Error(err))
+			continue
+		}
+		m.books.seed(assetID, *book)
+	}
+}
+
+type observation struct {
+	value  decimal.Decimal
+	price  decimal.Decimal
+	source string
+	move   priceMove
+	bid    *decimal.Decimal
+	ask    *decimal.Decimal
+	best   *decimal.Decimal
+	tick   *domain.TickSizeChange
+}
+
+func (m *AlertingManager) observe(alert *alertEval, event domain.MarketEvent, now time.Time) (observation, bool) {
+	switch alert.Kind {
+	case domain.AlertKindDepth:
+		depth, ok := m.books.depth(event.AssetID, alert.BookSide, alert.DepthRange)
+		if !ok {
+			return observation{}, false
+		}
+		return observation{value: depth.notional, price: depth.notional, best: depth.best}, true
+	case domain.AlertKindTrade:
+		return observeLastTrade(event)
+	case domain.AlertKindTick:
+		if event.TickSizeChange == nil {
+			return observation{}, false
+		}
+		tick := event.TickSizeChange
+		return observation{value: tick.NewTickSize, price: tick.NewTickSize, tick: tick}, true
+	}
+
+	if alert.Source == domain.PriceSourceLast {
+		return observeLastTrade(event)
+	}
+
+	if event.PriceChange == nil {
+		return observation{}, false
+	}
+	change := *event.PriceChange
+	switch alert.Kind {
+	case domain.AlertKindMove:
+		move, ok := m.windows.move(change.AssetID, alert.Direction, alert.Window, alert.Relative, now)
+		if !ok {
+			return observation{}, false
+		}
+		return observation{value: move.amount, price: move.to, move: move}, true
+	case domain.AlertKindSpread:
+		if change.BestBid == nil || change.BestAsk == nil {
+			return observation{}, false
+		}
+		spread := change.BestAsk.Sub(*change.BestBid)
+		return observation{value: spread, price: spread, bid: change.BestBid, ask: change.BestAsk}, true
+	default:
+		price, source := selectPrice(alert.Source, alert.Comparator, change)
+		if price == nil {
+			return observation{}, false
+		}
+		return observation{value: *price, price: *price, source: source}, true
+	}
+}
+
+func observeLastTrade(event domain.MarketEvent) (observation, bool) {
+	if event.LastTrade == nil {
+		return observation{}, false
+	}
+	price := event.LastTrade.Price
+	return observation{value: price, price: price, source: domain.PriceSourceLast}, true
 }
 
 type alertEval struct {
 	AlertID    uint
+	AssetID    string
+	EventSlug  string
+	EventWide  bool
 	MarketSlug string
 	Outcome    string
+	Kind       string
 	Comparator string
 	Threshold  decimal.Decimal
+	Source     string
+	Direction  string
+	Window     time.Duration
+	Relative   bool
+	BookSide   string
+	DepthRange decimal.Decimal
+	OneShot    bool
+	Cooldown   time.Duration
+	Hysteresis decimal.Decimal
+	Rearm      bool
+	Crossing   bool
+	UpdatedAt  time.Time
+
+	disarmed  bool
+	lastFired time.Time
+	done      bool
+	lastValue *decimal.Decimal
+	savedSide string
 }
 
-func (m *AlertingManager) runUser(ctx context.Context, user *domain.User, alerts []domain.Alert) {
-	assetAlerts := make(map[string][]alertEval)
-	assetIDs := make([]string, 0, len(alerts))
+type evalKey struct {
+	alertID uint
+	assetID string
+}
 
+func (e *alertEval) key() evalKey {
+	return evalKey{alertID: e.AlertID, assetID: e.AssetID}
+}
+
+func (m *AlertingManager) buildAssetAlerts(ctx context.Context, alerts []domain.Alert) map[string][]*alertEval {
+	assetAlerts := make(map[string][]*alertEval)
 	for _, alert := range alerts {
 		threshold, err := decimal.NewFromString(alert.Threshold)
 		if err != nil {
 			m.logger.Warn("invalid threshold on alert", zap.Uint("alert_id", alert.ID), zap.Error(err))
 			continue
 		}
-		eval := alertEval{
+		hysteresis := decimal.Zero
+		if alert.Hysteresis != "" {
+			hysteresis, err = decimal.NewFromString(alert.Hysteresis)
+			if err != nil {
+				m.logger.Warn("invalid hysteresis on alert", zap.Uint("alert_id", alert.ID), zap.Error(err))
+				continue
+			}
+		}
+		depthRange := decimal.Zero
+		if alert.DepthRange != "" {
+			depthRange, err = decimal.NewFromString(alert.DepthRange)
+			if err != nil {
+				m.logger.Warn("invalid depth range on alert", zap.Uint("alert_id", alert.ID), zap.Error(err))
+				continue
+			}
+		}
+		targets := []eventTarget{{MarketSlug: alert.MarketSlug, Outcome: alert.Outcome, AssetID: alert.AssetID}}
+		if alert.EventWide {
+			markets, err := m.events.markets(ctx, alert.EventSlug)
+			if err != nil {
+				m.logger.Warn("failed to load event markets for alert", zap.Uint("alert_id", alert.ID), zap.String("event_slug", alert.EventSlug), zap.Error(err))
+				continue
+			}
+			targets, err = expandEventMarkets(openMarkets(markets, time.Now()), alert.Outcome)
+			if err != nil {
+				m.logger.Warn("no event markets match alert", zap.Uint("alert_id", alert.ID), zap.String("event_slug", alert.EventSlug), zap.Error(err))
+				continue
+			}
+		}
+		base := alertEval{
 			AlertID:    alert.ID,
-			MarketSlug: alert.MarketSlug,
-			Outcome:    alert.Outcome,
+			EventSlug:  alert.EventSlug,
+			EventWide:  alert.EventWide,
+			Kind:       alert.Kind,
 			Comparator: alert.Comparator,
 			Threshold:  threshold,
+			Source:     alert.PriceSource,
+			Direction:  alert.Direction,
+			Window:     alert.Window,
+			Relative:   alert.Relative,
+			BookSide:   alert.BookSide,
+			DepthRange: depthRange,
+			OneShot:    alert.OneShot,
+			Cooldown:   alert.Cooldown,
+			Hysteresis: hysteresis,
+			Rearm:      alert.Rearm,
+			Crossing:   alert.Trigger == domain.TriggerCross,
+			UpdatedAt:  alert.UpdatedAt,
+		}
+		// Event-wide alerts share one stored side across their markets, so
+		// after a restart they wait to see a market re-arm before firing.
+		switch {
+		case base.Crossing && !base.EventWide:
+			base.savedSide = alert.LastSide
+			base.disarmed = alert.LastSide != restingSide(alert.Comparator)
+		case base.Crossing:
+			base.disarmed = true
+		case base.Rearm && !base.EventWide:
+			base.savedSide = alert.LastSide
+			base.disarmed = alert.LastSide == targetSide(alert.Comparator)
+		case base.Rearm:
+			base.disarmed = alert.LastFiredAt != nil
+		}
+		if alert.LastFiredAt != nil {
+			base.lastFired = *alert.LastFiredAt
+		}
+		for _, target := range targets {
+			eval := base
+			eval.AssetID = target.AssetID
+			eval.MarketSlug = target.MarketSlug
+			eval.Outcome = target.Outcome
+			assetAlerts[target.AssetID] = append(assetAlerts[target.AssetID], &eval)
 		}
-		assetAlerts[alert.AssetID] = append(assetAlerts[alert.AssetID], eval)
 	}
+	return assetAlerts
+}
 
-	for assetID := range assetAlerts {
-		assetIDs = append(assetIDs, assetID)
+func (e *alertEval) evaluate(price decimal.Decimal, now time.Time) bool {
+	e.lastValue = &price
+	if e.done {
+		return false
 	}
-
-	if len(assetIDs) == 0 {
-		return
+	if e.disarmed {
+		if !shouldRearm(e.Comparator, price, e.Threshold, e.Hysteresis) {
+			return false
+		}
+		e.disarmed = false
+	}
+	if !shouldNotify(e.Comparator, price, e.Threshold) {
+		return false
+	}
+	if !e.fire(now) {
+		return false
 	}
+	e.disarmed = e.Crossing || e.Rearm
+	return true
+}
 
-	client, err := m.wsFactory.Connect(ctx)
-	if err != nil {
-		m.logger.Error("failed to connect websocket", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
-		return
+func (e *alertEval) fire(now time.Time) bool {
+	if e.done {
+		return false
 	}
-	defer client.Close()
+	if e.Cooldown > 0 && !e.lastFired.IsZero() && now.Sub(e.lastFired) < e.Cooldown {
+		return false
+	}
+	e.lastFired = now
+	if e.OneShot {
+		e.done = true
+	}
+	return true
+}
 
-	go func() {
-		<-ctx.Done()
-		_ = client.Close()
-	}()
+// persistsSide reports whether the armed state is stored as the alert's last
+// side, which is only possible when the alert watches a single token. Tick
+// alerts have no level to be on a side of.
+func (e *alertEval) persistsSide() bool {
+	return (e.Crossing || e.Rearm) && !e.EventWide && e.Kind != domain.AlertKindTick
+}
 
-	if err := client.Subscribe(ctx, assetIDs); err != nil {
-		m.logger.Error("failed to subscribe websocket", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
-		return
+func (e *alertEval) side() string {
+	if e.disarmed {
+		return targetSide(e.Comparator)
 	}
+	return restingSide(e.Comparator)
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
+func (e *alertEval) triggerText(obs observation, previous *decimal.Decimal) string {
+	prefix := fmt.Sprintf("Alert #%d triggered: %s %s", e.AlertID, e.MarketSlug, e.Outcome)
+	rule := e.Comparator + " " + e.Threshold.String()
+	if e.Crossing {
+		rule = "crossed " + rule
+	}
 
-		msg, err := client.Receive(ctx)
-		if err != nil {
-			m.logger.Error("websocket receive error", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
-			return
+	switch e.Kind {
+	case domain.AlertKindMove:
+		direction := domain.DirectionUp
+		if obs.move.to.LessThan(obs.move.from) {
+			direction = domain.DirectionDown
 		}
-
-		if msg == nil || msg.EventType != "price_change" {
-			continue
+		amount := obs.move.amount.String()
+		if e.Relative {
+			amount = obs.move.amount.StringFixed(2) + "%"
+		}
+		return fmt.Sprintf("%s moved %s %s within %s (from %s to %s)", prefix, direction, amount, e.Window.String(), obs.move.from.String(), obs.move.to.String())
+	case domain.AlertKindDepth:
+		best := "n/a"
+		if obs.best != nil {
+			best = obs.best.String()
 		}
+		return fmt.Sprintf("%s %s depth within %s %s (depth $%s, best %s %s)", prefix, e.BookSide, e.DepthRange.String(), rule, obs.price.StringFixed(2), e.BookSide, best)
+	case domain.AlertKindSpread:
+		return fmt.Sprintf("%s spread %s (spread %s, bid %s ask %s)", prefix, rule, obs.price.String(), obs.bid.String(), obs.ask.String())
+	case domain.AlertKindTrade:
+		return fmt.Sprintf("%s last trade %s (trade price %s)", prefix, rule, obs.price.String())
+	case domain.AlertKindTick:
+		return fmt.Sprintf("%s tick size changed from %s to %s (price near extremes)", prefix, obs.tick.OldTickSize.String(), obs.tick.NewTickSize.String())
+	}
 
-		for _, change := range msg.PriceChanges {
-			alertsForAsset, ok := assetAlerts[change.AssetID]
-			if !ok {
-				continue
-			}
-			for _, alert := range alertsForAsset {
-				price := selectPrice(alert.Comparator, change)
-				if price == nil {
-					continue
-				}
-				if shouldNotify(alert.Comparator, *price, alert.Threshold) {
-					text := fmt.Sprintf(
-						"Alert #%d triggered: %s %s %s %s (price %s)",
-						alert.AlertID,
-						alert.MarketSlug,
-						alert.Outcome,
-						alert.Comparator,
-						alert.Threshold.String(),
-						price.String(),
-					)
-					if err := m.notifier.Notify(user.TelegramUserID, text); err != nil {
-						m.logger.Warn("failed to send alert", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
-					}
-				}
-			}
+	if e.Crossing {
+		from := "n/a"
+		if previous != nil {
+			from = previous.String()
 		}
+		return fmt.Sprintf("%s %s (%s %s, previous %s)", prefix, rule, obs.source, obs.price.String(), from)
 	}
+	return fmt.Sprintf("%s %s (%s %s)", prefix, rule, obs.source, obs.price.String())
 }
 
-func selectPrice(comparator string, change domain.PriceChange) *decimal.Decimal {
+func selectPrice(source string, comparator string, change domain.PriceChange) (*decimal.Decimal, string) {
+	switch source {
+	case domain.PriceSourceBid:
+		return change.BestBid, domain.PriceSourceBid
+	case domain.PriceSourceAsk:
+		return change.BestAsk, domain.PriceSourceAsk
+	case domain.PriceSourceMid:
+		return midPrice(change), domain.PriceSourceMid
+	}
+
 	if comparator == "<=" {
 		if change.BestAsk != nil {
-			return change.BestAsk
+			return change.BestAsk, domain.PriceSourceAsk
 		}
 	} else {
 		if change.BestBid != nil {
-			return change.BestBid
+			return change.BestBid, domain.PriceSourceBid
 		}
 	}
 	if change.Price != nil {
-		return change.Price
+		return change.Price, "price"
 	}
-	return nil
+	return nil, ""
+}
+
+func targetSide(comparator string) string {
+	if comparator == "<=" {
+		return domain.SideBelow
+	}
+	return domain.SideAbove
+}
+
+func restingSide(comparator string) string {
+	if comparator == "<=" {
+		return domain.SideAbove
+	}
+	return domain.SideBelow
+}
+
+func shouldRearm(comparator string, price decimal.Decimal, threshold decimal.Decimal, hysteresis decimal.Decimal) bool {
+	if comparator == "<=" {
+		return price.Cmp(threshold.Add(hysteresis)) > 0
+	}
+	return price.Cmp(threshold.Sub(hysteresis)) < 0
 }
 
 func shouldNotify(comparator string, price decimal.Decimal, threshold decimal.Decimal) bool {
@@ -261,3 +557,303 @@ func shouldNotify(comparator string, price decimal.Decimal, threshold decimal.De
 	}
 	return cmp >= 0
 }
+
+// watchQueueSize bounds the deliveries waiting for one user. When it fills
+// up, new ones are dropped rather than stalling the shared market stream.
+const watchQueueSize = 64
+
+// watchTask is the I/O that follows an evaluation. The delivery worker runs
+// tasks in order, off the hub's read loop.
+type watchTask struct {
+	alertID uint
+	side    string
+	text    string
+	trigger *domain.AlertTrigger
+	firedAt time.Time
+	oneShot bool
+}
+
+type userWatch struct {
+	manager *AlertingManager
+	user    *domain.User
+
+	mu     sync.Mutex
+	assets map[string][]*alertEval
+	queue  chan watchTask
+	closed bool
+}
+
+func newUserWatch(manager *AlertingManager, user *domain.User) *userWatch {
+	w := &userWatch{
+		manager: manager,
+		user:    user,
+		assets:  make(map[string][]*alertEval),
+		queue:   make(chan watchTask, watchQueueSize),
+	}
+	go w.run()
+	return w
+}
+
+// run delivers queued tasks until the watch is closed, so a slow Telegram
+// call holds up only this user.
+func (w *userWatch) run() {
+	ctx := context.Background()
+	for task := range w.queue {
+		w.deliver(ctx, task)
+	}
+}
+
+// close stops the worker once the tasks already queued are delivered.
+func (w *userWatch) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.queue)
+}
+
+// enqueue must be called with w.mu held.
+func (w *userWatch) enqueue(task watchTask) bool {
+	if w.closed {
+		return false
+	}
+	select {
+	case w.queue <- task:
+		return true
+	default:
+		w.manager.logger.Warn("alert delivery queue full, dropping", zap.Int64("telegram_user_id", w.user.TelegramUserID), zap.Uint("alert_id", task.alertID))
+		return false
+	}
+}
+
+func (w *userWatch) deliver(ctx context.Context, task watchTask) {
+	if task.side != "" {
+		w.saveSide(ctx, task.alertID, task.side)
+	}
+	if task.text == "" {
+		return
+	}
+	err := w.manager.notifier.Notify(w.user.TelegramUserID, task.text)
+	if err != nil {
+		w.manager.logger.Warn("failed to send alert", zap.Int64("telegram_user_id", w.user.TelegramUserID), zap.Uint("alert_id", task.alertID), zap.Error(err))
+	}
+	if task.trigger != nil {
+		w.recordTrigger(ctx, task.trigger, err)
+	}
+	switch {
+	case err != nil && task.oneShot:
+		w.mu.Lock()
+		w.rearmOneShot(task.alertID)
+		w.mu.Unlock()
+	case err != nil:
+	case task.oneShot:
+		w.disableOneShot(ctx, task.alertID)
+	case !task.firedAt.IsZero():
+		if err := w.manager.alerts.SetLastFired(ctx, w.user.ID, task.alertID, task.firedAt); err != nil {
+			w.manager.logger.Warn("failed to save alert fire time", zap.Uint("alert_id", task.alertID), zap.Error(err))
+		}
+	}
+}
+
+func (w *userWatch) assetIDs() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	assetIDs := make([]string, 0, len(w.assets))
+	for assetID := range w.assets {
+		assetIDs = append(assetIDs, assetID)
+	}
+	return assetIDs
+}
+
+func (w *userWatch) collectMarketState(spans map[string]time.Duration, books map[string]struct{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for assetID, evals := range w.assets {
+		for _, eval := range evals {
+			switch eval.Kind {
+			case domain.AlertKindMove:
+				if eval.Window > spans[assetID] {
+					spans[assetID] = eval.Window
+				}
+			case domain.AlertKindDepth:
+				books[assetID] = struct{}{}
+			}
+		}
+	}
+}
+
+func (w *userWatch) replace(assets map[string][]*alertEval) (added, removed []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous := make(map[evalKey]*alertEval)
+	for _, evals := range w.assets {
+		for _, eval := range evals {
+			previous[eval.key()] = eval
+		}
+	}
+	for _, evals := range assets {
+		for _, eval := range evals {
+			if old, ok := previous[eval.key()]; ok && old.UpdatedAt.Equal(eval.UpdatedAt) {
+				eval.disarmed = old.disarmed
+				eval.lastFired = old.lastFired
+				eval.done = old.done
+				eval.lastValue = old.lastValue
+				eval.savedSide = old.savedSide
+			}
+		}
+	}
+
+	for assetID := range assets {
+		if _, ok := w.assets[assetID]; !ok {
+			added = append(added, assetID)
+		}
+	}
+	for assetID := range w.assets {
+		if _, ok := assets[assetID]; !ok {
+			removed = append(removed, assetID)
+		}
+	}
+	w.assets = assets
+	return added, removed
+}
+
+func (w *userWatch) HandleMarketEvent(ctx context.Context, event domain.MarketEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	for _, alert := range w.assets[event.AssetID] {
+		obs, ok := w.manager.observe(alert, event, now)
+		if !ok {
+			continue
+		}
+		previous := alert.lastValue
+		var fired bool
+		if alert.Kind == domain.AlertKindTick {
+			fired = alert.fire(now)
+		} else {
+			fired = alert.evaluate(obs.value, now)
+		}
+		if alert.persistsSide() {
+			if side := alert.side(); side != alert.savedSide && w.enqueue(watchTask{alertID: alert.AlertID, side: side}) {
+				alert.savedSide = side
+			}
+		}
+		if !fired {
+			continue
+		}
+		text := alert.triggerText(obs, previous)
+		if alert.OneShot {
+			w.markDone(alert.AlertID)
+			text += fmt.Sprintf("\nOne-shot alert disabled. Use /enable %d to re-arm it.", alert.AlertID)
+		}
+		task := watchTask{alertID: alert.AlertID, text: text, trigger: w.newTrigger(alert, event, obs, now), firedAt: now, oneShot: alert.OneShot}
+		if !w.enqueue(task) && alert.OneShot {
+			w.rearmOneShot(alert.AlertID)
+		}
+	}
+}
+
+// newTrigger captures a firing together with the quote it fired on. The
+// delivery status is filled in once the notification has been sent.
+func (w *userWatch) newTrigger(alert *alertEval, event domain.MarketEvent, obs observation, now time.Time) *domain.AlertTrigger {
+	trigger := &domain.AlertTrigger{
+		UserID:      w.user.ID,
+		AlertID:     alert.AlertID,
+		MarketSlug:  alert.MarketSlug,
+		Outcome:     alert.Outcome,
+		AssetID:     alert.AssetID,
+		Kind:        alert.Kind,
+		Price:       obs.price.String(),
+		TriggeredAt: now,
+	}
+	bid, ask := obs.bid, obs.ask
+	if event.PriceChange != nil {
+		bid, ask = event.PriceChange.BestBid, event.PriceChange.BestAsk
+	}
+	if bid != nil {
+		trigger.BestBid = bid.String()
+	}
+	if ask != nil {
+		trigger.BestAsk = ask.String()
+	}
+	return trigger
+}
+
+// recordTrigger stores a firing in the audit log with whether the
+// notification reached the user.
+func (w *userWatch) recordTrigger(ctx context.Context, trigger *domain.AlertTrigger, sendErr error) {
+	trigger.Status = domain.DeliveryDelivered
+	if sendErr != nil {
+		trigger.Status = domain.DeliveryFailed
+		trigger.Error = sendErr.Error()
+	}
+	if err := w.manager.triggers.Create(ctx, trigger); err != nil {
+		w.manager.logger.Warn("failed to record alert trigger", zap.Uint("alert_id", trigger.AlertID), zap.Error(err))
+	}
+}
+
+// saveSide persists the side an evaluation recorded as saved. On failure the
+// saved side is cleared, so the next event retries the write.
+func (w *userWatch) saveSide(ctx context.Context, alertID uint, side string) {
+	err := w.manager.alerts.SetLastSide(ctx, w.user.ID, alertID, side)
+	if err == nil {
+		return
+	}
+	w.manager.logger.Warn("failed to save alert side", zap.Uint("alert_id", alertID), zap.Error(err))
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, evals := range w.assets {
+		for _, eval := range evals {
+			if eval.AlertID == alertID {
+				eval.savedSide = ""
+			}
+		}
+	}
+}
+
+// markDone stops every expansion of an alert, so a one-shot event-wide alert
+// does not fire again for other markets before it is disabled.
+func (w *userWatch) markDone(alertID uint) {
+	for _, evals := range w.assets {
+		for _, eval := range evals {
+			if eval.AlertID == alertID {
+				eval.done = true
+			}
+		}
+	}
+}
+
+// rearmOneShot lets a one-shot alert fire again after its notification could
+// not be delivered; w.mu must be held.
+func (w *userWatch) rearmOneShot(alertID uint) {
+	for _, evals := range w.assets {
+		for _, eval := range evals {
+			if eval.AlertID == alertID {
+				eval.done = false
+				eval.lastFired = time.Time{}
+			}
+		}
+	}
+}
+
+func (w *userWatch) disableOneShot(ctx context.Context, alertID uint) {
+	if err := w.manager.alerts.SetEnabled(ctx, w.user.ID, alertID, false); err != nil {
+		w.manager.logger.Warn("failed to disable one-shot alert", zap.Uint("alert_id", alertID), zap.Error(err))
+		return
+	}
+	w.manager.syncUser(ctx, w.user)
+}
+
+func (w *userWatch) HandleStatus(ctx context.Context, healthy bool) {
+	text := "Monitoring degraded: connection to Polymarket lost, reconnecting. Alerts may be delayed."
+	if healthy {
+		text = "Monitoring restored: connection to Polymarket is back."
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.enqueue(watchTask{text: text})
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"go.uber.org/zap"
+)
+
+const eventOutcomeAny = "any"
+
+type eventTarget struct {
+	MarketSlug string
+	Outcome    string
+	AssetID    string
+}
+
+// expandEventMarkets resolves an event-wide alert to concrete tokens. An empty
+// outcome selects every outcome of every market; otherwise the outcome is
+// matched per market and markets without a match are skipped.
+func expandEventMarkets(markets []domain.MarketInfo, outcome string) ([]eventTarget, error) {
+	var targets []eventTarget
+	var labels []string
+	seen := make(map[string]struct{})
+	for _, market := range markets {
+		outcomes := market.Outcomes
+		if len(outcomes) == 0 {
+			outcomes = binaryOutcomes
+		}
+		if len(market.ClobTokenIDs) < len(outcomes) {
+			continue
+		}
+		for _, label := range outcomes {
+			if _, ok := seen[label]; !ok {
+				seen[label] = struct{}{}
+				labels = append(labels, label)
+			}
+		}
+
+		if outcome == "" {
+			for i, label := range outcomes {
+				targets = append(targets, eventTarget{MarketSlug: market.Slug, Outcome: label, AssetID: market.ClobTokenIDs[i]})
+			}
+			continue
+		}
+		if index, ok := matchOutcome(outcomes, outcome); ok {
+			targets = append(targets, eventTarget{MarketSlug: market.Slug, Outcome: outcomes[index], AssetID: market.ClobTokenIDs[index]})
+		}
+	}
+	if outcome != "" && len(targets) == 0 {
+		return nil, &OutcomeError{Outcome: outcome, Valid: labels}
+	}
+	return targets, nil
+}
+
+// defaultEventCacheTTL applies when the event refresh interval is disabled,
+// so cached events still expire and new markets are eventually picked up.
+const defaultEventCacheTTL = 5 * time.Minute
+
+// EventCache holds Gamma events for the features that poll them: event-wide
+// alerts, the market check and the event watcher. A caller states how old a
+// copy it accepts, so an event followed by several features is fetched about
+// once per poll rather than once per feature. Entries never outlive the TTL.
+type EventCache struct {
+	gamma  domain.GammaClient
+	ttl    time.Duration
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	events map[string]cachedEvent
+}
+
+type cachedEvent struct {
+	event     *domain.EventMarkets
+	fetchedAt time.Time
+}
+
+func NewEventCache(gamma domain.GammaClient, ttl time.Duration, logger *zap.Logger) *EventCache {
+	if ttl <= 0 {
+		ttl = defaultEventCacheTTL
+	}
+	return &EventCache{gamma: gamma, ttl: ttl, logger: logger, events: make(map[string]cachedEvent)}
+}
+
+// Event returns the cached event if it is younger than maxAge and the TTL,
+// and refetches it otherwise. If the refetch fails, the stale copy is
+// returned and the error logged.
+func (c *EventCache) Event(ctx context.Context, slug string, maxAge time.Duration) (*domain.EventMarkets, error) {
+	now := time.Now()
+	c.mu.Lock()
+	cached, ok := c.events[slug]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < min(maxAge, c.ttl) {
+		return cached.event, nil
+	}
+
+	event, err := c.gamma.GetEventBySlug(ctx, slug)
+	if err != nil {
+		if ok {
+			c.logger.Warn("failed to refresh event, using cached markets", zap.String("event_slug", slug), zap.Error(err))
+			return cached.event, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.events[slug] = cachedEvent{event: event, fetchedAt: now}
+	c.mu.Unlock()
+	return event, nil
+}
+
+func (c *EventCache) markets(ctx context.Context, slug string) ([]domain.MarketInfo, error) {
+	event, err := c.Event(ctx, slug, c.ttl)
+	if err != nil {
+		return nil, err
+	}
+	return event.Markets, nil
+}
+
+// refresh refetches the given events and forgets expired entries nobody
+// asked for.
+func (c *EventCache) refresh(ctx context.Context, slugs map[string]struct{}) {
+	for slug := range slugs {
+		if _, err := c.Event(ctx, slug, 0); err != nil {
+			c.logger.Warn("failed to refresh event markets", zap.String("event_slug", slug), zap.Error(err))
+		}
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for slug, cached := range c.events {
+		if _, ok := slugs[slug]; !ok && now.Sub(cached.fetchedAt) >= c.ttl {
+			delete(c.events, slug)
+		}
+	}
+}
+
+func (m *AlertingManager) runEventRefresh(ctx context.Context) {
+	if m.eventRefresh <= 0 {
+		return
+	}
+	ticker := time.NewTicker(m.eventRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		users := make([]*domain.User, 0, len(m.eventUsers))
+		slugs := make(map[string]struct{})
+		for _, entry := range m.eventUsers {
+			users = append(users, entry.user)
+			for slug := range entry.slugs {
+				slugs[slug] = struct{}{}
+			}
+		}
+		m.mu.Unlock()
+
+		if len(users) == 0 {
+			continue
+		}
+		m.events.refresh(ctx, slugs)
+		for _, user := range users {
+			m.syncUser(ctx, user)
+		}
+	}
+}
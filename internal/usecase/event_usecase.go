@@ -3,10 +3,15 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/NasaVasa/botty/internal/domain"
 )
 
+const searchPageSize = 10
+
+var ErrInvalidQuery = errors.New("invalid search query")
+
 type EventUsecase struct {
 	gamma domain.GammaClient
 }
@@ -25,3 +30,14 @@ func (u *EventUsecase) GetEvent(ctx context.Context, eventSlug string) (*domain.
 	}
 	return event, nil
 }
+
+func (u *EventUsecase) SearchEvents(ctx context.Context, query string, page int) (*domain.EventSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrInvalidQuery
+	}
+	if page < 1 {
+		page = 1
+	}
+	return u.gamma.SearchEvents(ctx, query, page, searchPageSize)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/NasaVasa/botty/internal/domain"
+)
+
+var (
+	ErrAlreadyWatching = errors.New("event already watched")
+	ErrWatchNotFound   = errors.New("event watch not found")
+)
+
+type EventWatchUsecase struct {
+	users   domain.UserRepository
+	watches domain.EventWatchRepository
+	gamma   domain.GammaClient
+}
+
+func NewEventWatchUsecase(users domain.UserRepository, watches domain.EventWatchRepository, gamma domain.GammaClient) *EventWatchUsecase {
+	return &EventWatchUsecase{users: users, watches: watches, gamma: gamma}
+}
+
+// WatchEvent subscribes the user to market changes of an event. The current
+// markets become the baseline, so only later changes are reported.
+func (u *EventWatchUsecase) WatchEvent(ctx context.Context, telegramUserID int64, eventSlug string) (*domain.EventWatch, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	eventSlug = strings.TrimSpace(eventSlug)
+	existing, err := u.watches.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, watch := range existing {
+		if watch.EventSlug == eventSlug {
+			return nil, ErrAlreadyWatching
+		}
+	}
+
+	event, err := u.gamma.GetEventBySlug(ctx, eventSlug)
+	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	watch := &domain.EventWatch{
+		UserID:    user.ID,
+		EventSlug: eventSlug,
+		Markets:   marketStatuses(event.Markets),
+	}
+	if err := u.watches.Create(ctx, watch); err != nil {
+		return nil, err
+	}
+	return watch, nil
+}
+
+func (u *EventWatchUsecase) UnwatchEvent(ctx context.Context, telegramUserID int64, eventSlug string) error {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrUserNotRegistered
+		}
+		return err
+	}
+
+	if err := u.watches.Delete(ctx, user.ID, strings.TrimSpace(eventSlug)); err != nil {
+		if err == domain.ErrNotFound {
+			return ErrWatchNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *EventWatchUsecase) ListWatches(ctx context.Context, telegramUserID int64) ([]domain.EventWatch, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	return u.watches.ListByUser(ctx, user.ID)
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type marketChange struct {
+	status string
+	isNew  bool
+	market domain.MarketInfo
+}
+
+// EventWatcher polls watched events and notifies subscribers when markets are
+// added, closed or resolved.
+type EventWatcher struct {
+	users    domain.UserRepository
+	watches  domain.EventWatchRepository
+	events   *EventCache
+	notifier Notifier
+	interval time.Duration
+	logger   *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewEventWatcher(users domain.UserRepository, watches domain.EventWatchRepository, events *EventCache, notifier Notifier, interval time.Duration, logger *zap.Logger) *EventWatcher {
+	return &EventWatcher{
+		users:    users,
+		watches:  watches,
+		events:   events,
+		notifier: notifier,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (w *EventWatcher) Start(ctx context.Context) {
+	if w.interval <= 0 {
+		w.logger.Info("event watcher disabled")
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+func (w *EventWatcher) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *EventWatcher) run(ctx context.Context) {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+func (w *EventWatcher) poll(ctx context.Context) {
+	watches, err := w.watches.ListAll(ctx)
+	if err != nil {
+		w.logger.Warn("failed to load event watches", zap.Error(err))
+		return
+	}
+
+	bySlug := make(map[string][]domain.EventWatch)
+	for _, watch := range watches {
+		bySlug[watch.EventSlug] = append(bySlug[watch.EventSlug], watch)
+	}
+
+	for slug, group := range bySlug {
+		if ctx.Err() != nil {
+			return
+		}
+		// A copy fetched since the last poll, e.g. by the event alert
+		// refresh, is recent enough.
+		event, err := w.events.Event(ctx, slug, w.interval/2)
+		if err != nil {
+			w.logger.Warn("failed to poll watched event", zap.String("event_slug", slug), zap.Error(err))
+			continue
+		}
+		for _, watch := range group {
+			w.apply(ctx, watch, event)
+		}
+	}
+}
+
+func (w *EventWatcher) apply(ctx context.Context, watch domain.EventWatch, event *domain.EventMarkets) {
+	statuses := marketStatuses(event.Markets)
+	if maps.Equal(statuses, watch.Markets) {
+		return
+	}
+	if err := w.watches.UpdateMarkets(ctx, watch.ID, statuses); err != nil {
+		w.logger.Warn("failed to save event watch", zap.Uint("watch_id", watch.ID), zap.Error(err))
+		return
+	}
+
+	changes := diffMarkets(watch.Markets, event.Markets)
+	if len(changes) == 0 {
+		return
+	}
+
+	user, err := w.users.GetByID(ctx, watch.UserID)
+	if err != nil {
+		w.logger.Warn("failed to load user for event watch", zap.Uint("user_id", watch.UserID), zap.Error(err))
+		return
+	}
+	if err := w.notifier.Notify(user.TelegramUserID, formatMarketChanges(watch.EventSlug, event.Title, changes)); err != nil {
+		w.logger.Warn("failed to send event update", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
+	}
+}
+
+func diffMarkets(previous map[string]string, markets []domain.MarketInfo) []marketChange {
+	var changes []marketChange
+	for _, market := range markets {
+		status := marketStatus(market)
+		old, known := previous[market.Slug]
+		switch {
+		case !known:
+			changes = append(changes, marketChange{status: status, isNew: true, market: market})
+		case old != status && status != domain.MarketStatusOpen:
+			changes = append(changes, marketChange{status: status, market: market})
+		}
+	}
+	return changes
+}
+
+func formatMarketChanges(eventSlug, title string, changes []marketChange) string {
+	var builder strings.Builder
+	if title != "" {
+		builder.WriteString(fmt.Sprintf("Event %s (%s) updated:", title, eventSlug))
+	} else {
+		builder.WriteString(fmt.Sprintf("Event %s updated:", eventSlug))
+	}
+	for _, change := range changes {
+		builder.WriteString("\n")
+		switch {
+		case change.isNew:
+			builder.WriteString("New market: " + change.market.Slug)
+			if question := strings.TrimSpace(change.market.Question); question != "" {
+				builder.WriteString(" - " + question)
+			}
+			if change.status != domain.MarketStatusOpen {
+				builder.WriteString(" (" + change.status + ")")
+			}
+		case change.status == domain.MarketStatusResolved:
+			builder.WriteString("Resolved: " + change.market.Slug)
+			if winner, ok := winningOutcome(change.market); ok {
+				builder.WriteString(" (winner: " + winner + ")")
+			}
+		default:
+			builder.WriteString("Closed: " + change.market.Slug)
+		}
+	}
+	return builder.String()
+}
+
+func marketStatuses(markets []domain.MarketInfo) map[string]string {
+	statuses := make(map[string]string, len(markets))
+	for _, market := range markets {
+		statuses[market.Slug] = marketStatus(market)
+	}
+	return statuses
+}
+
+func marketStatus(market domain.MarketInfo) string {
+	switch {
+	case market.Resolved:
+		return domain.MarketStatusResolved
+	case market.Closed:
+		return domain.MarketStatusClosed
+	default:
+		return domain.MarketStatusOpen
+	}
+}
+
+// winningOutcome returns the outcome settled at 1 on a resolved market.
+func winningOutcome(market domain.MarketInfo) (string, bool) {
+	outcomes := market.Outcomes
+	if len(outcomes) == 0 {
+		outcomes = binaryOutcomes
+	}
+	for i, price := range market.OutcomePrices {
+		if i >= len(outcomes) {
+			break
+		}
+		value, err := decimal.NewFromString(strings.TrimSpace(price))
+		if err == nil && value.Equal(decimal.NewFromInt(1)) {
+			return outcomes[i], true
+		}
+	}
+	return "", false
+}
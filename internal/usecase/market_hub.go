@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"go.uber.org/zap"
+)
+
+// MarketListener receives events on the hub's read loop, which serves every
+// asset of a connection: handlers must return quickly and leave network and
+// database work to their own goroutines.
+type MarketListener interface {
+	HandleMarketEvent(ctx context.Context, event domain.MarketEvent)
+	HandleStatus(ctx context.Context, healthy bool)
+}
+
+type MarketObserver interface {
+	ObserveMarketEvent(ctx context.Context, event domain.MarketEvent)
+}
+
+type MarketHub struct {
+	factory          domain.MarketWSFactory
+	maxAssetsPerConn int
+	minBackoff       time.Duration
+	maxBackoff       time.Duration
+	logger           *zap.Logger
+
+	opMu       sync.Mutex
+	mu         sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	listeners  map[string]map[MarketListener]struct{}
+	observers  []MarketObserver
+	assetConns map[string]*hubConn
+	conns      map[int]*hubConn
+	nextConnID int
+	// unhealthy holds the listeners last told that monitoring is degraded.
+	unhealthy map[MarketListener]struct{}
+	wg        sync.WaitGroup
+}
+
+type hubConn struct {
+	id     int
+	assets map[string]struct{}
+	cancel context.CancelFunc
+	// degraded is guarded by the hub's mu.
+	degraded bool
+
+	mu     sync.Mutex
+	client domain.MarketWSClient
+}
+
+func NewMarketHub(factory domain.MarketWSFactory, maxAssetsPerConn int, minBackoff, maxBackoff time.Duration, logger *zap.Logger) *MarketHub {
+	if maxAssetsPerConn <= 0 {
+		maxAssetsPerConn = 1
+	}
+	if minBackoff <= 0 {
+		minBackoff = time.Second
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+	return &MarketHub{
+		factory:          factory,
+		maxAssetsPerConn: maxAssetsPerConn,
+		minBackoff:       minBackoff,
+		maxBackoff:       maxBackoff,
+		logger:           logger,
+		listeners:        make(map[string]map[MarketListener]struct{}),
+		assetConns:       make(map[string]*hubConn),
+		conns:            make(map[int]*hubConn),
+		unhealthy:        make(map[MarketListener]struct{}),
+	}
+}
+
+func (h *MarketHub) Start(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ctx != nil {
+		return
+	}
+	h.ctx, h.cancel = context.WithCancel(ctx)
+	for _, conn := range h.conns {
+		h.launch(conn)
+	}
+}
+
+func (h *MarketHub) Stop() {
+	h.mu.Lock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		h.logger.Warn("timeout stopping market hub")
+	}
+}
+
+func (h *MarketHub) AddObserver(observer MarketObserver) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.observers = append(h.observers, observer)
+}
+
+// Subscribed reports whether an asset is streamed on a healthy connection,
+// that is, whether quotes observed for it are current.
+func (h *MarketHub) Subscribed(assetID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	conn, ok := h.assetConns[assetID]
+	return ok && !conn.degraded
+}
+
+func (h *MarketHub) Subscribe(ctx context.Context, listener MarketListener, assetIDs []string) {
+	h.opMu.Lock()
+	defer h.opMu.Unlock()
+
+	added := make(map[*hubConn][]string)
+
+	h.mu.Lock()
+	for _, assetID := range assetIDs {
+		set, ok := h.listeners[assetID]
+		if !ok {
+			set = make(map[MarketListener]struct{})
+			h.listeners[assetID] = set
+		}
+		set[listener] = struct{}{}
+
+		if _, assigned := h.assetConns[assetID]; assigned {
+			continue
+		}
+		conn := h.connWithCapacity()
+		conn.assets[assetID] = struct{}{}
+		h.assetConns[assetID] = conn
+		added[conn] = append(added[conn], assetID)
+	}
+	// An asset may already sit on, or have joined, a degraded connection.
+	changes := h.statusChanges(map[MarketListener]bool{listener: true})
+	h.mu.Unlock()
+
+	for listener, healthy := range changes {
+		listener.HandleStatus(ctx, healthy)
+	}
+
+	for conn, ids := range added {
+		conn.mu.Lock()
+		if conn.client != nil {
+			if err := conn.client.Subscribe(ctx, ids); err != nil {
+				h.logger.Error("failed to subscribe websocket", zap.Int("conn_id", conn.id), zap.Error(err))
+			}
+		}
+		conn.mu.Unlock()
+	}
+}
+
+func (h *MarketHub) Unsubscribe(ctx context.Context, listener MarketListener, assetIDs []string) {
+	h.opMu.Lock()
+	defer h.opMu.Unlock()
+
+	removed := make(map[*hubConn][]string)
+
+	h.mu.Lock()
+	for _, assetID := range assetIDs {
+		set, ok := h.listeners[assetID]
+		if !ok {
+			continue
+		}
+		delete(set, listener)
+		if len(set) > 0 {
+			continue
+		}
+		delete(h.listeners, assetID)
+
+		conn, ok := h.assetConns[assetID]
+		if !ok {
+			continue
+		}
+		delete(h.assetConns, assetID)
+		delete(conn.assets, assetID)
+		if len(conn.assets) == 0 {
+			delete(h.conns, conn.id)
+			delete(removed, conn)
+			if conn.cancel != nil {
+				conn.cancel()
+			}
+			continue
+		}
+		removed[conn] = append(removed[conn], assetID)
+	}
+	// Dropping the assets of a degraded connection can leave the listener
+	// healthy; one with no assets left needs no notice.
+	var changes map[MarketListener]bool
+	if h.hasAssets(listener) {
+		changes = h.statusChanges(map[MarketListener]bool{listener: true})
+	} else {
+		delete(h.unhealthy, listener)
+	}
+	h.mu.Unlock()
+
+	for listener, healthy := range changes {
+		listener.HandleStatus(ctx, healthy)
+	}
+
+	for conn, ids := range removed {
+		conn.mu.Lock()
+		if conn.client != nil {
+			if err := conn.client.Unsubscribe(ctx, ids); err != nil {
+				h.logger.Error("failed to unsubscribe websocket", zap.Int("conn_id", conn.id), zap.Error(err))
+			}
+		}
+		conn.mu.Unlock()
+	}
+}
+
+func (h *MarketHub) connWithCapacity() *hubConn {
+	for _, conn := range h.conns {
+		if len(conn.assets) < h.maxAssetsPerConn && !conn.degraded {
+			return conn
+		}
+	}
+
+	h.nextConnID++
+	conn := &hubConn{id: h.nextConnID, assets: make(map[string]struct{})}
+	h.conns[conn.id] = conn
+	if h.ctx != nil {
+		h.launch(conn)
+	}
+	return conn
+}
+
+func (h *MarketHub) launch(conn *hubConn) {
+	connCtx, cancel := context.WithCancel(h.ctx)
+	conn.cancel = cancel
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.runConn(connCtx, conn)
+	}()
+}
+
+func (h *MarketHub) connAssets(conn *hubConn) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	assetIDs := make([]string, 0, len(conn.assets))
+	for assetID := range conn.assets {
+		assetIDs = append(assetIDs, assetID)
+	}
+	return assetIDs
+}
+
+func (h *MarketHub) resubscribe(ctx context.Context, conn *hubConn) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if conn.client == nil {
+		return nil
+	}
+	assetIDs := h.connAssets(conn)
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	if err := conn.client.Subscribe(ctx, assetIDs); err != nil {
+		h.logger.Error("failed to subscribe websocket", zap.Int("conn_id", conn.id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *MarketHub) runConn(ctx context.Context, conn *hubConn) {
+	attempt := 0
+	for {
+		connected := h.serveConn(ctx, conn)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			attempt = 0
+		}
+		h.setDegraded(ctx, conn, true)
+
+		delay := h.backoff(attempt)
+		attempt++
+		h.logger.Warn("websocket reconnect scheduled", zap.Int("conn_id", conn.id), zap.Int("attempt", attempt), zap.Duration("delay", delay))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (h *MarketHub) serveConn(ctx context.Context, conn *hubConn) bool {
+	client, err := h.factory.Connect(ctx)
+	if err != nil {
+		h.logger.Error("failed to connect websocket", zap.Int("conn_id", conn.id), zap.Error(err))
+		return false
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		_ = client.Close()
+	}()
+
+	conn.mu.Lock()
+	conn.client = client
+	conn.mu.Unlock()
+	defer func() {
+		conn.mu.Lock()
+		conn.client = nil
+		conn.mu.Unlock()
+	}()
+
+	if err := h.resubscribe(ctx, conn); err != nil {
+		return false
+	}
+	h.setDegraded(ctx, conn, false)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		default:
+		}
+
+		events, err := client.Receive(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Error("websocket receive error", zap.Int("conn_id", conn.id), zap.Error(err))
+			}
+			return true
+		}
+
+		h.dispatch(ctx, events)
+	}
+}
+
+func (h *MarketHub) backoff(attempt int) time.Duration {
+	delay := h.minBackoff
+	for i := 0; i < attempt && delay < h.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > h.maxBackoff {
+		delay = h.maxBackoff
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+func (h *MarketHub) setDegraded(ctx context.Context, conn *hubConn, degraded bool) {
+	h.mu.Lock()
+	changed := conn.degraded != degraded
+	conn.degraded = degraded
+	var changes map[MarketListener]bool
+	if changed {
+		listeners := make(map[MarketListener]bool)
+		for assetID := range conn.assets {
+			for listener := range h.listeners[assetID] {
+				listeners[listener] = true
+			}
+		}
+		changes = h.statusChanges(listeners)
+	}
+	h.mu.Unlock()
+	if !changed {
+		return
+	}
+
+	if degraded {
+		h.logger.Warn("websocket monitoring degraded", zap.Int("conn_id", conn.id))
+	} else {
+		h.logger.Info("websocket monitoring restored", zap.Int("conn_id", conn.id))
+	}
+	for listener, healthy := range changes {
+		listener.HandleStatus(ctx, healthy)
+	}
+}
+
+// statusChanges works out, for each of the given listeners (all mapped to
+// true), whether all of its connections are healthy, and returns those whose
+// status changed since they were last told. A listener spread over several
+// connections thus hears "degraded" once and "restored" only when the last of
+// them is back. It must be called with h.mu held.
+func (h *MarketHub) statusChanges(listeners map[MarketListener]bool) map[MarketListener]bool {
+	for assetID, set := range h.listeners {
+		assigned, ok := h.assetConns[assetID]
+		if !ok || !assigned.degraded {
+			continue
+		}
+		for listener := range set {
+			if _, ok := listeners[listener]; ok {
+				listeners[listener] = false
+			}
+		}
+	}
+
+	changes := make(map[MarketListener]bool)
+	for listener, healthy := range listeners {
+		_, wasUnhealthy := h.unhealthy[listener]
+		if healthy == !wasUnhealthy {
+			continue
+		}
+		if healthy {
+			delete(h.unhealthy, listener)
+		} else {
+			h.unhealthy[listener] = struct{}{}
+		}
+		changes[listener] = healthy
+	}
+	return changes
+}
+
+// hasAssets must be called with h.mu held.
+func (h *MarketHub) hasAssets(listener MarketListener) bool {
+	for _, set := range h.listeners {
+		if _, ok := set[listener]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *MarketHub) dispatch(ctx context.Context, events []domain.MarketEvent) {
+	for _, event := range events {
+		observers, listeners := h.receiversFor(event.AssetID)
+		if len(listeners) == 0 {
+			continue
+		}
+		for _, observer := range observers {
+			observer.ObserveMarketEvent(ctx, event)
+		}
+		for _, listener := range listeners {
+			listener.HandleMarketEvent(ctx, event)
+		}
+	}
+}
+
+func (h *MarketHub) receiversFor(assetID string) ([]MarketObserver, []MarketListener) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set := h.listeners[assetID]
+	listeners := make([]MarketListener, 0, len(set))
+	for listener := range set {
+		listeners = append(listeners, listener)
+	}
+	return h.observers, listeners
+}
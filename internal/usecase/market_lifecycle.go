@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"go.uber.org/zap"
+)
+
+type alertRef struct {
+	watch   *userWatch
+	alertID uint
+}
+
+// marketFinished reports whether a market no longer trades: it is closed or
+// resolved, or inactive past its end date.
+func marketFinished(market domain.MarketInfo, now time.Time) bool {
+	if market.Closed || market.Resolved {
+		return true
+	}
+	return !market.Active && market.EndDate != nil && now.After(*market.EndDate)
+}
+
+func openMarkets(markets []domain.MarketInfo, now time.Time) []domain.MarketInfo {
+	open := make([]domain.MarketInfo, 0, len(markets))
+	for _, market := range markets {
+		if !marketFinished(market, now) {
+			open = append(open, market)
+		}
+	}
+	return open
+}
+
+func (m *AlertingManager) runMarketCheck(ctx context.Context) {
+	if m.marketCheck <= 0 {
+		return
+	}
+	ticker := time.NewTicker(m.marketCheck)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkMarkets(ctx)
+		}
+	}
+}
+
+// checkMarkets disables alerts whose market has closed or resolved, so they
+// stop holding WS subscriptions, and tells the user how the market ended.
+func (m *AlertingManager) checkMarkets(ctx context.Context) {
+	m.mu.Lock()
+	watches := make([]*userWatch, 0, len(m.watches))
+	for _, watch := range m.watches {
+		watches = append(watches, watch)
+	}
+	m.mu.Unlock()
+
+	markets := make(map[string][]alertRef)
+	events := make(map[string][]alertRef)
+	for _, watch := range watches {
+		watch.collectAlertRefs(markets, events)
+	}
+
+	now := time.Now()
+	affected := make(map[*userWatch]struct{})
+	for slug, refs := range markets {
+		if ctx.Err() != nil {
+			return
+		}
+		market, err := m.gamma.GetMarketBySlug(ctx, slug)
+		if err != nil {
+			m.logger.Warn("failed to check market state", zap.String("market_slug", slug), zap.Error(err))
+			continue
+		}
+		if !marketFinished(*market, now) {
+			if market.EndDate != nil {
+				m.updateEndDates(ctx, refs, *market.EndDate)
+			}
+			continue
+		}
+		for _, ref := range refs {
+			text := fmt.Sprintf("Alert #%d disabled: market %s %s.", ref.alertID, slug, describeFinish(*market))
+			if m.finishAlert(ctx, ref, text) {
+				affected[ref.watch] = struct{}{}
+			}
+		}
+	}
+	for slug, refs := range events {
+		eventMarkets, err := m.events.markets(ctx, slug)
+		if err != nil || len(eventMarkets) == 0 || len(openMarkets(eventMarkets, now)) > 0 {
+			continue
+		}
+		for _, ref := range refs {
+			text := fmt.Sprintf("Alert #%d disabled: all markets of event %s are closed.", ref.alertID, slug)
+			if m.finishAlert(ctx, ref, text) {
+				affected[ref.watch] = struct{}{}
+			}
+		}
+	}
+
+	for watch := range affected {
+		m.syncUser(ctx, watch.user)
+	}
+}
+
+func (m *AlertingManager) finishAlert(ctx context.Context, ref alertRef, text string) bool {
+	user := ref.watch.user
+	if err := m.alerts.SetEnabled(ctx, user.ID, ref.alertID, false); err != nil {
+		m.logger.Warn("failed to disable alert on finished market", zap.Uint("alert_id", ref.alertID), zap.Error(err))
+		return false
+	}
+	if err := m.notifier.Notify(user.TelegramUserID, text); err != nil {
+		m.logger.Warn("failed to send market finish notice", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
+	}
+	return true
+}
+
+// updateEndDates keeps stored end dates current, which also fills them in for
+// alerts created before end dates were recorded. Pending reminders follow the
+// new date.
+func (m *AlertingManager) updateEndDates(ctx context.Context, refs []alertRef, endDate time.Time) {
+	for _, ref := range refs {
+		if err := m.alerts.SetEndDate(ctx, ref.alertID, endDate); err != nil {
+			m.logger.Warn("failed to update alert end date", zap.Uint("alert_id", ref.alertID), zap.Error(err))
+			continue
+		}
+		if err := m.reminders.Reschedule(ctx, ref.alertID, endDate); err != nil {
+			m.logger.Warn("failed to reschedule reminders", zap.Uint("alert_id", ref.alertID), zap.Error(err))
+		}
+	}
+}
+
+func describeFinish(market domain.MarketInfo) string {
+	if market.Resolved {
+		if winner, ok := winningOutcome(market); ok {
+			return "resolved, winner: " + winner
+		}
+		return "resolved"
+	}
+	if market.Closed || market.EndDate == nil {
+		return "is closed"
+	}
+	return "ended on " + market.EndDate.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func (w *userWatch) collectAlertRefs(markets map[string][]alertRef, events map[string][]alertRef) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen := make(map[uint]struct{})
+	for _, evals := range w.assets {
+		for _, eval := range evals {
+			if _, ok := seen[eval.AlertID]; ok {
+				continue
+			}
+			seen[eval.AlertID] = struct{}{}
+			ref := alertRef{watch: w, alertID: eval.AlertID}
+			if eval.EventWide {
+				events[eval.EventSlug] = append(events[eval.EventSlug], ref)
+			} else {
+				markets[eval.MarketSlug] = append(markets[eval.MarketSlug], ref)
+			}
+		}
+	}
+}
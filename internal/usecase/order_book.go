@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type bookDepth struct {
+	notional decimal.Decimal
+	best     *decimal.Decimal
+}
+
+type localBook struct {
+	bids map[string]domain.OrderLevel
+	asks map[string]domain.OrderLevel
+}
+
+type orderBooks struct {
+	mu      sync.Mutex
+	tracked map[string]struct{}
+	books   map[string]*localBook
+}
+
+func newOrderBooks() *orderBooks {
+	return &orderBooks{
+		tracked: make(map[string]struct{}),
+		books:   make(map[string]*localBook),
+	}
+}
+
+// setTracked replaces the tracked assets and returns the ones that just
+// started being tracked. Their book event may already have gone by on a shared
+// subscription, so the caller seeds them with seed.
+func (b *orderBooks) setTracked(tracked map[string]struct{}) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var added []string
+	for assetID := range tracked {
+		if _, ok := b.tracked[assetID]; !ok {
+			added = append(added, assetID)
+		}
+	}
+	b.tracked = tracked
+	for assetID := range b.books {
+		if _, ok := tracked[assetID]; !ok {
+			delete(b.books, assetID)
+		}
+	}
+	return added
+}
+
+// seed installs a book fetched over REST unless the asset is no longer
+// tracked or a book event, which is more recent, has already arrived.
+func (b *orderBooks) seed(assetID string, snapshot domain.OrderBook) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.tracked[assetID]; !ok {
+		return
+	}
+	if _, ok := b.books[assetID]; ok {
+		return
+	}
+	b.books[assetID] = newLocalBook(snapshot)
+}
+
+func (b *orderBooks) ObserveMarketEvent(ctx context.Context, event domain.MarketEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.tracked[event.AssetID]; !ok {
+		return
+	}
+
+	switch {
+	case event.Book != nil:
+		b.books[event.AssetID] = newLocalBook(*event.Book)
+	case event.PriceChange != nil:
+		change := event.PriceChange
+		book, ok := b.books[event.AssetID]
+		if !ok || change.Price == nil || change.Size == nil {
+			return
+		}
+		level := domain.OrderLevel{Price: *change.Price, Size: *change.Size}
+		switch change.Side {
+		case domain.OrderSideBuy:
+			setLevel(book.bids, level)
+		case domain.OrderSideSell:
+			setLevel(book.asks, level)
+		}
+	}
+}
+
+func (b *orderBooks) depth(assetID string, side string, priceRange decimal.Decimal) (bookDepth, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	book, ok := b.books[assetID]
+	if !ok {
+		return bookDepth{}, false
+	}
+
+	levels := book.asks
+	if side == domain.BookSideBid {
+		levels = book.bids
+	}
+
+	var best *decimal.Decimal
+	for _, level := range levels {
+		if best == nil || (side == domain.BookSideBid && level.Price.GreaterThan(*best)) || (side != domain.BookSideBid && level.Price.LessThan(*best)) {
+			price := level.Price
+			best = &price
+		}
+	}
+	if best == nil {
+		return bookDepth{notional: decimal.Zero}, true
+	}
+
+	notional := decimal.Zero
+	for _, level := range levels {
+		distance := level.Price.Sub(*best).Abs()
+		if distance.GreaterThan(priceRange) {
+			continue
+		}
+		notional = notional.Add(level.Price.Mul(level.Size))
+	}
+	return bookDepth{notional: notional, best: best}, true
+}
+
+func newLocalBook(snapshot domain.OrderBook) *localBook {
+	book := &localBook{
+		bids: make(map[string]domain.OrderLevel, len(snapshot.Bids)),
+		asks: make(map[string]domain.OrderLevel, len(snapshot.Asks)),
+	}
+	for _, level := range snapshot.Bids {
+		setLevel(book.bids, level)
+	}
+	for _, level := range snapshot.Asks {
+		setLevel(book.asks, level)
+	}
+	return book
+}
+
+func setLevel(levels map[string]domain.OrderLevel, level domain.OrderLevel) {
+	key := level.Price.String()
+	if !level.Size.IsPositive() {
+		delete(levels, key)
+		return
+	}
+	levels[key] = level
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/NasaVasa/botty/internal/domain"
+)
+
+// OutcomeError reports an outcome that does not match any label of the market.
+type OutcomeError struct {
+	Outcome string
+	Valid   []string
+}
+
+func (e *OutcomeError) Error() string {
+	return fmt.Sprintf("invalid outcome %q", e.Outcome)
+}
+
+func (e *OutcomeError) Unwrap() error {
+	return ErrInvalidOutcome
+}
+
+var binaryOutcomes = []string{"Yes", "No"}
+
+// minOutcomeQuery is the shortest input matched by prefix or substring, so a
+// stray letter such as "o" does not silently pick "No".
+const minOutcomeQuery = 3
+
+func mapOutcomeToAssetID(market domain.MarketInfo, outcome string) (string, string, error) {
+	outcomes := market.Outcomes
+	if len(outcomes) == 0 {
+		outcomes = binaryOutcomes
+	}
+	if len(market.ClobTokenIDs) < len(outcomes) {
+		return "", "", fmt.Errorf("market %s: %d token ids for %d outcomes", market.Slug, len(market.ClobTokenIDs), len(outcomes))
+	}
+
+	index, ok := matchOutcome(outcomes, outcome)
+	if !ok {
+		return "", "", &OutcomeError{Outcome: strings.TrimSpace(outcome), Valid: outcomes}
+	}
+	return market.ClobTokenIDs[index], outcomes[index], nil
+}
+
+// matchOutcome resolves user input to an outcome index. It tries an exact
+// case-insensitive match, then the same after dropping punctuation and spaces,
+// then a unique prefix or substring of at least minOutcomeQuery runes, and
+// finally a unique closest label within a small edit distance. Ambiguous input
+// never matches.
+func matchOutcome(outcomes []string, input string) (int, bool) {
+	input = strings.TrimSpace(input)
+	for i, outcome := range outcomes {
+		if strings.EqualFold(strings.TrimSpace(outcome), input) {
+			return i, true
+		}
+	}
+
+	query := outcomeKey(input)
+	if query == "" {
+		return 0, false
+	}
+	keys := make([]string, len(outcomes))
+	for i, outcome := range outcomes {
+		keys[i] = outcomeKey(outcome)
+	}
+
+	if i, ok := uniqueOutcome(keys, func(key string) bool { return key == query }); ok {
+		return i, true
+	}
+	if len([]rune(query)) < minOutcomeQuery {
+		return 0, false
+	}
+	if i, ok := uniqueOutcome(keys, func(key string) bool { return strings.HasPrefix(key, query) }); ok {
+		return i, true
+	}
+	if i, ok := uniqueOutcome(keys, func(key string) bool { return strings.Contains(key, query) }); ok {
+		return i, true
+	}
+
+	maxDistance := min(2, len([]rune(query))/3)
+	best, bestDistance, ties := -1, maxDistance+1, 0
+	for i, key := range keys {
+		distance := editDistance(query, key)
+		switch {
+		case distance < bestDistance:
+			best, bestDistance, ties = i, distance, 1
+		case distance == bestDistance:
+			ties++
+		}
+	}
+	if best < 0 || ties > 1 {
+		return 0, false
+	}
+	return best, true
+}
+
+func uniqueOutcome(keys []string, match func(key string) bool) (int, bool) {
+	found := -1
+	for i, key := range keys {
+		if key == "" || !match(key) {
+			continue
+		}
+		if found >= 0 {
+			return 0, false
+		}
+		found = i
+	}
+	return found, found >= 0
+}
+
+func outcomeKey(value string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidPeriod     = errors.New("invalid chart period")
+	ErrNoPriceHistory    = errors.New("no price history")
+	ErrChartNotSupported = errors.New("chart not supported for alert")
+)
+
+const (
+	defaultChartPeriod = "24h"
+	// chartResolution is the number of CLOB history points requested per
+	// chart, whatever its period.
+	chartResolution = 240
+
+	HistorySourceSnapshots = "snapshots"
+	HistorySourceCLOB      = "CLOB"
+)
+
+var chartPeriods = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+// PriceHistory is the mid price of one outcome token over a period. Points
+// are in time order; each holds until the next one. Threshold is set when
+// the history was requested for an alert whose threshold is a price.
+type PriceHistory struct {
+	MarketSlug string
+	Outcome    string
+	Period     string
+	Source     string
+	From       time.Time
+	To         time.Time
+	Points     []domain.PricePoint
+	AlertID    uint
+	Comparator string
+	Threshold  *decimal.Decimal
+}
+
+// AlertPriceHistory returns the history of the token an alert watches.
+func (u *PriceUsecase) AlertPriceHistory(ctx context.Context, telegramUserID int64, alertID uint, period string) (*PriceHistory, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	alerts, err := u.alerts.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var alert *domain.Alert
+	for i := range alerts {
+		if alerts[i].ID == alertID {
+			alert = &alerts[i]
+			break
+		}
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+	if alert.EventWide || alert.AssetID == "" {
+		return nil, ErrChartNotSupported
+	}
+
+	history, err := u.priceHistory(ctx, alert.AssetID, period)
+	if err != nil {
+		return nil, err
+	}
+	history.MarketSlug = alert.MarketSlug
+	history.Outcome = alert.Outcome
+	history.AlertID = alert.ID
+	// Only price and trade thresholds share the chart's price axis.
+	if alert.Kind == domain.AlertKindPrice || alert.Kind == domain.AlertKindTrade {
+		if threshold, err := decimal.NewFromString(alert.Threshold); err == nil {
+			history.Comparator = alert.Comparator
+			history.Threshold = &threshold
+		}
+	}
+	return history, nil
+}
+
+// MarketPriceHistory returns the history of a market's first outcome.
+func (u *PriceUsecase) MarketPriceHistory(ctx context.Context, eventSlug, marketSlug, period string) (*PriceHistory, error) {
+	market, err := u.lookupMarket(ctx, eventSlug, marketSlug)
+	if err != nil {
+		return nil, err
+	}
+	if len(market.ClobTokenIDs) == 0 {
+		return nil, ErrNoPriceHistory
+	}
+
+	history, err := u.priceHistory(ctx, market.ClobTokenIDs[0], period)
+	if err != nil {
+		return nil, err
+	}
+	history.MarketSlug = market.Slug
+	history.Outcome = binaryOutcomes[0]
+	if len(market.Outcomes) > 0 {
+		history.Outcome = market.Outcomes[0]
+	}
+	return history, nil
+}
+
+func (u *PriceUsecase) priceHistory(ctx context.Context, assetID string, period string) (*PriceHistory, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if period == "" {
+		period = defaultChartPeriod
+	}
+	span, ok := chartPeriods[period]
+	if !ok {
+		return nil, ErrInvalidPeriod
+	}
+
+	to := time.Now()
+	from := to.Add(-span)
+	snapshots, err := u.snapshots.ListRange(ctx, assetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// The last snapshot before the period holds the price at its start.
+	covered := false
+	if first, err := u.snapshots.LatestBefore(ctx, assetID, from); err == nil && !first.TakenAt.Before(from.Add(-span)) {
+		first.TakenAt = from
+		snapshots = append([]domain.PriceSnapshot{*first}, snapshots...)
+		covered = true
+	} else if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+	if live, ok := u.recorder.Quote(assetID); ok {
+		live.TakenAt = to
+		snapshots = append(snapshots, live)
+	}
+
+	history := &PriceHistory{Period: period, From: from, To: to, Source: HistorySourceSnapshots}
+	for _, snapshot := range snapshots {
+		if mid := snapshot.Mid(); mid != nil {
+			history.Points = append(history.Points, domain.PricePoint{At: snapshot.TakenAt, Price: *mid})
+		}
+	}
+	// Snapshots only exist while the bot streams a token. When they do not
+	// reach back to the start of the period, the CLOB history is fuller.
+	if !covered {
+		points, err := u.clob.GetPriceHistory(ctx, assetID, from, to, max(span/chartResolution, time.Minute))
+		if err != nil && !errors.Is(err, domain.ErrBookNotFound) {
+			u.logger.Warn("failed to load price history", zap.String("asset_id", assetID), zap.Error(err))
+		}
+		if len(points) > 0 {
+			history.Points = points
+			history.Source = HistorySourceCLOB
+		}
+	}
+	if len(history.Points) == 0 {
+		return nil, ErrNoPriceHistory
+	}
+	return history, nil
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const snapshotPruneInterval = time.Hour
+
+type liveQuote struct {
+	snapshot domain.PriceSnapshot
+	dirty    bool
+}
+
+// PriceRecorder keeps the latest quote of every streamed asset and writes it
+// to the snapshot store at most once per interval, and only when it changed.
+// Snapshots older than the retention are pruned.
+type PriceRecorder struct {
+	hub       *MarketHub
+	snapshots domain.PriceSnapshotRepository
+	interval  time.Duration
+	retention time.Duration
+	logger    *zap.Logger
+
+	mu        sync.Mutex
+	quotes    map[string]*liveQuote
+	lastPrune time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPriceRecorder(hub *MarketHub, snapshots domain.PriceSnapshotRepository, interval, retention time.Duration, logger *zap.Logger) *PriceRecorder {
+	r := &PriceRecorder{
+		hub:       hub,
+		snapshots: snapshots,
+		interval:  interval,
+		retention: retention,
+		logger:    logger,
+		quotes:    make(map[string]*liveQuote),
+	}
+	hub.AddObserver(r)
+	return r
+}
+
+func (r *PriceRecorder) Start(ctx context.Context) {
+	if r.interval <= 0 {
+		r.logger.Info("price recorder disabled")
+		return
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go r.run(ctx)
+}
+
+func (r *PriceRecorder) Stop() {
+	if r.cancel == nil {
+		return
+	}
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *PriceRecorder) ObserveMarketEvent(ctx context.Context, event domain.MarketEvent) {
+	var bid, ask, last *decimal.Decimal
+	switch {
+	case event.PriceChange != nil:
+		bid, ask = event.PriceChange.BestBid, event.PriceChange.BestAsk
+	case event.Book != nil:
+		bid, ask = bestLevels(*event.Book)
+	case event.LastTrade != nil:
+		price := event.LastTrade.Price
+		last = &price
+	default:
+		return
+	}
+	if bid == nil && ask == nil && last == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	quote, ok := r.quotes[event.AssetID]
+	if !ok {
+		quote = &liveQuote{snapshot: domain.PriceSnapshot{AssetID: event.AssetID}}
+		r.quotes[event.AssetID] = quote
+	}
+	if bid != nil {
+		quote.snapshot.BestBid = bid
+	}
+	if ask != nil {
+		quote.snapshot.BestAsk = ask
+	}
+	if last != nil {
+		quote.snapshot.LastTrade = last
+	}
+	quote.snapshot.TakenAt = time.Now()
+	quote.dirty = true
+}
+
+// Quote returns the live quote of an asset. It reports false when the asset
+// is not streamed right now, since a cached quote would be stale then.
+func (r *PriceRecorder) Quote(assetID string) (domain.PriceSnapshot, bool) {
+	if !r.hub.Subscribed(assetID) {
+		return domain.PriceSnapshot{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	quote, ok := r.quotes[assetID]
+	if !ok {
+		return domain.PriceSnapshot{}, false
+	}
+	return quote.snapshot, true
+}
+
+func (r *PriceRecorder) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.flush(ctx)
+		}
+	}
+}
+
+func (r *PriceRecorder) flush(ctx context.Context) {
+	r.mu.Lock()
+	assetIDs := make([]string, 0, len(r.quotes))
+	for assetID := range r.quotes {
+		assetIDs = append(assetIDs, assetID)
+	}
+	r.mu.Unlock()
+	// The hub is asked without holding r.mu, since it may block on a
+	// connection that is busy subscribing.
+	streamed := make(map[string]bool, len(assetIDs))
+	for _, assetID := range assetIDs {
+		streamed[assetID] = r.hub.Subscribed(assetID)
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	var batch []domain.PriceSnapshot
+	for assetID, quote := range r.quotes {
+		if subscribed, known := streamed[assetID]; known && !subscribed && now.Sub(quote.snapshot.TakenAt) > r.interval {
+			delete(r.quotes, assetID)
+			continue
+		}
+		if !quote.dirty {
+			continue
+		}
+		snapshot := quote.snapshot
+		snapshot.TakenAt = now
+		batch = append(batch, snapshot)
+		quote.dirty = false
+	}
+	prune := r.retention > 0 && now.Sub(r.lastPrune) >= snapshotPruneInterval
+	if prune {
+		r.lastPrune = now
+	}
+	r.mu.Unlock()
+
+	if err := r.snapshots.CreateBatch(ctx, batch); err != nil {
+		r.logger.Warn("failed to store price snapshots", zap.Int("count", len(batch)), zap.Error(err))
+	}
+	if prune {
+		deleted, err := r.snapshots.DeleteBefore(ctx, now.Add(-r.retention))
+		if err != nil {
+			r.logger.Warn("failed to prune price snapshots", zap.Error(err))
+			return
+		}
+		r.logger.Debug("pruned price snapshots", zap.Int64("count", deleted))
+	}
+}
+
+func bestLevels(book domain.OrderBook) (*decimal.Decimal, *decimal.Decimal) {
+	var bid, ask *decimal.Decimal
+	for _, level := range book.Bids {
+		if level.Size.IsPositive() && (bid == nil || level.Price.GreaterThan(*bid)) {
+			price := level.Price
+			bid = &price
+		}
+	}
+	for _, level := range book.Asks {
+		if level.Size.IsPositive() && (ask == nil || level.Price.LessThan(*ask)) {
+			price := level.Price
+			ask = &price
+		}
+	}
+	return bid, ask
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	QuoteSourceLive  = "live"
+	QuoteSourceCLOB  = "CLOB"
+	QuoteSourceGamma = "Gamma"
+)
+
+// changeFidelity is the resolution of CLOB price history used for 1h and 24h
+// changes of tokens without stored snapshots.
+const changeFidelity = 5 * time.Minute
+
+// OutcomeQuote is the current quote of one outcome. Source tells whether it
+// comes from the WebSocket stream, the CLOB order book or, when the CLOB is
+// unavailable, Gamma. Changes are nil when no price close enough to the
+// period start is known.
+type OutcomeQuote struct {
+	Outcome   string
+	AssetID   string
+	BestBid   *decimal.Decimal
+	BestAsk   *decimal.Decimal
+	LastTrade *decimal.Decimal
+	Source    string
+	Change1h  *decimal.Decimal
+	Change24h *decimal.Decimal
+}
+
+type MarketQuote struct {
+	Market domain.MarketInfo
+	Quotes []OutcomeQuote
+}
+
+type PriceUsecase struct {
+	users     domain.UserRepository
+	alerts    domain.AlertRepository
+	gamma     domain.GammaClient
+	clob      domain.CLOBClient
+	recorder  *PriceRecorder
+	snapshots domain.PriceSnapshotRepository
+	logger    *zap.Logger
+}
+
+func NewPriceUsecase(users domain.UserRepository, alerts domain.AlertRepository, gamma domain.GammaClient, clob domain.CLOBClient, recorder *PriceRecorder, snapshots domain.PriceSnapshotRepository, logger *zap.Logger) *PriceUsecase {
+	return &PriceUsecase{users: users, alerts: alerts, gamma: gamma, clob: clob, recorder: recorder, snapshots: snapshots, logger: logger}
+}
+
+// GetMarketQuote returns a quote per outcome of a market. An empty market
+// selects the only market of a single-market event.
+func (u *PriceUsecase) GetMarketQuote(ctx context.Context, eventSlug, marketSlug string) (*MarketQuote, error) {
+	market, err := u.lookupMarket(ctx, eventSlug, marketSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := market.Outcomes
+	if len(outcomes) == 0 {
+		outcomes = binaryOutcomes
+	}
+	now := time.Now()
+	result := &MarketQuote{Market: market}
+	for i, outcome := range outcomes {
+		if i >= len(market.ClobTokenIDs) {
+			break
+		}
+		quote := OutcomeQuote{Outcome: outcome, AssetID: market.ClobTokenIDs[i], Source: QuoteSourceLive}
+		snapshot, live := u.recorder.Quote(quote.AssetID)
+		if !live {
+			snapshot, quote.Source = u.restQuote(ctx, market, i, len(outcomes))
+		}
+		quote.BestBid, quote.BestAsk, quote.LastTrade = snapshot.BestBid, snapshot.BestAsk, snapshot.LastTrade
+		if current := snapshot.Mid(); current != nil {
+			quote.Change1h = u.change(ctx, quote.AssetID, *current, now, time.Hour)
+			quote.Change24h = u.change(ctx, quote.AssetID, *current, now, 24*time.Hour)
+			if quote.Change1h == nil || quote.Change24h == nil {
+				u.historyChanges(ctx, &quote, *current, now)
+			}
+		}
+		result.Quotes = append(result.Quotes, quote)
+	}
+	return result, nil
+}
+
+func (u *PriceUsecase) lookupMarket(ctx context.Context, eventSlug, marketSlug string) (domain.MarketInfo, error) {
+	event, err := u.gamma.GetEventBySlug(ctx, eventSlug)
+	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return domain.MarketInfo{}, ErrEventNotFound
+		}
+		return domain.MarketInfo{}, err
+	}
+
+	if strings.TrimSpace(marketSlug) == "" && len(event.Markets) == 1 {
+		return event.Markets[0], nil
+	}
+	market, ok := findMarketBySlug(marketSlug, event)
+	if !ok {
+		return domain.MarketInfo{}, ErrMarketNotInEvent
+	}
+	return market, nil
+}
+
+// change compares the current price with the last snapshot taken before the
+// period started. Snapshots are only written when the quote moves, so one up
+// to a period older still reflects the price at that time; anything older is
+// treated as a gap in recording.
+func (u *PriceUsecase) change(ctx context.Context, assetID string, current decimal.Decimal, now time.Time, period time.Duration) *decimal.Decimal {
+	start := now.Add(-period)
+	snapshot, err := u.snapshots.LatestBefore(ctx, assetID, start)
+	if err != nil {
+		if err != domain.ErrNotFound {
+			u.logger.Warn("failed to load price snapshot", zap.String("asset_id", assetID), zap.Error(err))
+		}
+		return nil
+	}
+	if snapshot.TakenAt.Before(start.Add(-period)) {
+		return nil
+	}
+	past := snapshot.Mid()
+	if past == nil {
+		return nil
+	}
+	delta := current.Sub(*past)
+	return &delta
+}
+
+// restQuote reads the best bid and ask of a token that is not streamed from
+// its CLOB order book. Gamma fills in the last trade, and the whole quote when
+// the CLOB cannot be reached.
+func (u *PriceUsecase) restQuote(ctx context.Context, market domain.MarketInfo, index int, outcomes int) (domain.PriceSnapshot, string) {
+	snapshot := gammaSnapshot(market, index, outcomes)
+	book, err := u.clob.GetOrderBook(ctx, market.ClobTokenIDs[index])
+	if err != nil {
+		if !errors.Is(err, domain.ErrBookNotFound) {
+			u.logger.Warn("failed to load order book", zap.String("asset_id", market.ClobTokenIDs[index]), zap.Error(err))
+		}
+		return snapshot, QuoteSourceGamma
+	}
+	snapshot.BestBid, snapshot.BestAsk = bestLevels(*book)
+	return snapshot, QuoteSourceCLOB
+}
+
+// historyChanges fills the changes that snapshots could not answer from the
+// CLOB price history of the last 24 hours.
+func (u *PriceUsecase) historyChanges(ctx context.Context, quote *OutcomeQuote, current decimal.Decimal, now time.Time) {
+	points, err := u.clob.GetPriceHistory(ctx, quote.AssetID, now.Add(-25*time.Hour), now, changeFidelity)
+	if err != nil {
+		if !errors.Is(err, domain.ErrBookNotFound) {
+			u.logger.Warn("failed to load price history", zap.String("asset_id", quote.AssetID), zap.Error(err))
+		}
+		return
+	}
+	if quote.Change1h == nil {
+		quote.Change1h = changeSince(points, current, now.Add(-time.Hour), time.Hour)
+	}
+	if quote.Change24h == nil {
+		quote.Change24h = changeSince(points, current, now.Add(-24*time.Hour), 24*time.Hour)
+	}
+}
+
+// changeSince compares current with the last point at or before start, which
+// must be less than a period older than start.
+func changeSince(points []domain.PricePoint, current decimal.Decimal, start time.Time, period time.Duration) *decimal.Decimal {
+	var past *domain.PricePoint
+	for i := range points {
+		if points[i].At.After(start) {
+			break
+		}
+		past = &points[i]
+	}
+	if past == nil || past.At.Before(start.Add(-period)) {
+		return nil
+	}
+	delta := current.Sub(past.Price)
+	return &delta
+}
+
+// gammaSnapshot builds a quote from Gamma's market fields, which describe
+// the first outcome. The second outcome of a binary market mirrors it.
+func gammaSnapshot(market domain.MarketInfo, index int, outcomes int) domain.PriceSnapshot {
+	snapshot := domain.PriceSnapshot{TakenAt: time.Now()}
+	switch {
+	case index == 0:
+		snapshot.BestBid, snapshot.BestAsk, snapshot.LastTrade = market.BestBid, market.BestAsk, market.LastTrade
+	case index == 1 && outcomes == 2:
+		snapshot.BestBid = complementPrice(market.BestAsk)
+		snapshot.BestAsk = complementPrice(market.BestBid)
+		snapshot.LastTrade = complementPrice(market.LastTrade)
+	}
+	if snapshot.Mid() == nil && index < len(market.OutcomePrices) {
+		if price, err := decimal.NewFromString(market.OutcomePrices[index]); err == nil {
+			snapshot.LastTrade = &price
+		}
+	}
+	return snapshot
+}
+
+func complementPrice(price *decimal.Decimal) *decimal.Decimal {
+	if price == nil {
+		return nil
+	}
+	complement := decimal.NewFromInt(1).Sub(*price)
+	return &complement
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+const minSampleInterval = time.Second
+
+type priceSample struct {
+	at    time.Time
+	price decimal.Decimal
+}
+
+type priceMove struct {
+	amount decimal.Decimal
+	from   decimal.Decimal
+	to     decimal.Decimal
+}
+
+type priceWindows struct {
+	mu      sync.Mutex
+	spans   map[string]time.Duration
+	samples map[string][]priceSample
+}
+
+func newPriceWindows() *priceWindows {
+	return &priceWindows{
+		spans:   make(map[string]time.Duration),
+		samples: make(map[string][]priceSample),
+	}
+}
+
+func (p *priceWindows) setSpans(spans map[string]time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spans = spans
+	for assetID := range p.samples {
+		if _, ok := spans[assetID]; !ok {
+			delete(p.samples, assetID)
+		}
+	}
+}
+
+func (p *priceWindows) ObserveMarketEvent(ctx context.Context, event domain.MarketEvent) {
+	if event.PriceChange == nil {
+		return
+	}
+	change := *event.PriceChange
+	price := midPrice(change)
+	if price == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	span, ok := p.spans[change.AssetID]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	samples := p.samples[change.AssetID]
+	if n := len(samples); n > 0 && samples[n-1].price.Equal(*price) && now.Sub(samples[n-1].at) < minSampleInterval {
+		return
+	}
+	samples = append(samples, priceSample{at: now, price: *price})
+
+	cutoff := now.Add(-span)
+	first := 0
+	for first < len(samples)-1 && samples[first+1].at.Before(cutoff) {
+		first++
+	}
+	p.samples[change.AssetID] = append(samples[:0], samples[first:]...)
+}
+
+func (p *priceWindows) move(assetID string, direction string, window time.Duration, relative bool, now time.Time) (priceMove, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	samples := p.samples[assetID]
+	if len(samples) < 2 {
+		return priceMove{}, false
+	}
+
+	latest := samples[len(samples)-1].price
+	low, high := latest, latest
+	cutoff := now.Add(-window)
+	for i := len(samples) - 1; i >= 0; i-- {
+		if samples[i].price.LessThan(low) {
+			low = samples[i].price
+		}
+		if samples[i].price.GreaterThan(high) {
+			high = samples[i].price
+		}
+		if samples[i].at.Before(cutoff) {
+			break
+		}
+	}
+
+	up := priceMove{amount: latest.Sub(low), from: low, to: latest}
+	down := priceMove{amount: high.Sub(latest), from: high, to: latest}
+	if relative {
+		up.amount = relativeMove(up.amount, low)
+		down.amount = relativeMove(down.amount, high)
+	}
+
+	switch direction {
+	case domain.DirectionUp:
+		return up, true
+	case domain.DirectionDown:
+		return down, true
+	default:
+		if down.amount.GreaterThan(up.amount) {
+			return down, true
+		}
+		return up, true
+	}
+}
+
+func relativeMove(amount decimal.Decimal, base decimal.Decimal) decimal.Decimal {
+	if !base.IsPositive() {
+		return decimal.Zero
+	}
+	return amount.Div(base).Mul(decimal.NewFromInt(100))
+}
+
+func midPrice(change domain.PriceChange) *decimal.Decimal {
+	if change.BestBid != nil && change.BestAsk != nil {
+		mid := change.BestBid.Add(*change.BestAsk).Div(decimal.NewFromInt(2))
+		return &mid
+	}
+	return change.Price
+}
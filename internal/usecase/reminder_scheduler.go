@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+	"go.uber.org/zap"
+)
+
+// ReminderScheduler sends due end-date reminders. It runs on its own ticker
+// and does not depend on WebSocket traffic.
+type ReminderScheduler struct {
+	users     domain.UserRepository
+	alerts    domain.AlertRepository
+	reminders domain.ReminderRepository
+	notifier  Notifier
+	interval  time.Duration
+	logger    *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReminderScheduler(users domain.UserRepository, alerts domain.AlertRepository, reminders domain.ReminderRepository, notifier Notifier, interval time.Duration, logger *zap.Logger) *ReminderScheduler {
+	return &ReminderScheduler{
+		users:     users,
+		alerts:    alerts,
+		reminders: reminders,
+		notifier:  notifier,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+func (s *ReminderScheduler) Start(ctx context.Context) {
+	if s.interval <= 0 {
+		s.logger.Info("reminder scheduler disabled")
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *ReminderScheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *ReminderScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.sendDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sendDue(ctx)
+		}
+	}
+}
+
+func (s *ReminderScheduler) sendDue(ctx context.Context) {
+	now := time.Now()
+	due, err := s.reminders.ListDue(ctx, now)
+	if err != nil {
+		s.logger.Warn("failed to load due reminders", zap.Error(err))
+		return
+	}
+
+	for _, reminder := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.reminders.MarkSent(ctx, reminder.ID, now); err != nil {
+			s.logger.Warn("failed to mark reminder sent", zap.Uint("reminder_id", reminder.ID), zap.Error(err))
+			continue
+		}
+		if !reminder.EndDate.After(now) {
+			continue
+		}
+		if !s.alertExists(ctx, reminder) {
+			continue
+		}
+
+		user, err := s.users.GetByID(ctx, reminder.UserID)
+		if err != nil {
+			s.logger.Warn("failed to load user for reminder", zap.Uint("user_id", reminder.UserID), zap.Error(err))
+			continue
+		}
+		text := fmt.Sprintf(
+			"Reminder: market %s closes in %s (%s). Alert #%d.",
+			reminder.MarketSlug,
+			FormatOffset(reminder.EndDate.Sub(now)),
+			reminder.EndDate.UTC().Format("2006-01-02 15:04 UTC"),
+			reminder.AlertID,
+		)
+		if err := s.notifier.Notify(user.TelegramUserID, text); err != nil {
+			s.logger.Warn("failed to send reminder", zap.Int64("telegram_user_id", user.TelegramUserID), zap.Error(err))
+		}
+	}
+}
+
+func (s *ReminderScheduler) alertExists(ctx context.Context, reminder domain.Reminder) bool {
+	alerts, err := s.alerts.ListByUser(ctx, reminder.UserID)
+	if err != nil {
+		s.logger.Warn("failed to load alerts for reminder", zap.Uint("reminder_id", reminder.ID), zap.Error(err))
+		return false
+	}
+	for _, alert := range alerts {
+		if alert.ID == reminder.AlertID {
+			return true
+		}
+	}
+	return false
+}
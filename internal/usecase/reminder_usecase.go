@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NasaVasa/botty/internal/domain"
+)
+
+var (
+	ErrInvalidReminder  = errors.New("invalid reminder offset")
+	ErrNoEndDate        = errors.New("market has no end date")
+	ErrReminderInPast   = errors.New("reminder time already passed")
+	ErrReminderNotFound = errors.New("reminder not found")
+)
+
+const maxReminderOffset = 365 * 24 * time.Hour
+
+type ReminderUsecase struct {
+	users     domain.UserRepository
+	alerts    domain.AlertRepository
+	reminders domain.ReminderRepository
+}
+
+func NewReminderUsecase(users domain.UserRepository, alerts domain.AlertRepository, reminders domain.ReminderRepository) *ReminderUsecase {
+	return &ReminderUsecase{users: users, alerts: alerts, reminders: reminders}
+}
+
+// AddReminder schedules a notification the given offset (e.g. 24h or 2d)
+// before the end date of the alert's market.
+func (u *ReminderUsecase) AddReminder(ctx context.Context, telegramUserID int64, alertID uint, before string) (*domain.Reminder, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	offset, err := parseReminderOffset(before)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := u.alerts.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var alert *domain.Alert
+	for i := range alerts {
+		if alerts[i].ID == alertID {
+			alert = &alerts[i]
+			break
+		}
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+	if alert.EndDate == nil {
+		return nil, ErrNoEndDate
+	}
+
+	remindAt := alert.EndDate.Add(-offset)
+	if !remindAt.After(time.Now()) {
+		return nil, ErrReminderInPast
+	}
+
+	reminder := &domain.Reminder{
+		UserID:     user.ID,
+		AlertID:    alert.ID,
+		MarketSlug: alert.MarketSlug,
+		EndDate:    *alert.EndDate,
+		Before:     offset,
+		RemindAt:   remindAt,
+	}
+	if err := u.reminders.Create(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+func (u *ReminderUsecase) ListReminders(ctx context.Context, telegramUserID int64) ([]domain.Reminder, error) {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrUserNotRegistered
+		}
+		return nil, err
+	}
+
+	return u.reminders.ListByUser(ctx, user.ID)
+}
+
+func (u *ReminderUsecase) DeleteReminder(ctx context.Context, telegramUserID int64, reminderID uint) error {
+	user, err := u.users.GetByTelegramID(ctx, telegramUserID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return ErrUserNotRegistered
+		}
+		return err
+	}
+
+	if err := u.reminders.Delete(ctx, user.ID, reminderID); err != nil {
+		if err == domain.ErrNotFound {
+			return ErrReminderNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// parseReminderOffset accepts Go durations plus a leading day count, e.g.
+// 24h, 90m, 2d or 1d12h.
+func parseReminderOffset(input string) (time.Duration, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	var offset time.Duration
+	if days, rest, ok := strings.Cut(value, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, ErrInvalidReminder
+		}
+		offset = time.Duration(count) * 24 * time.Hour
+		value = rest
+	}
+	if value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, ErrInvalidReminder
+		}
+		offset += parsed
+	}
+	if offset <= 0 || offset > maxReminderOffset {
+		return 0, ErrInvalidReminder
+	}
+	return offset, nil
+}
+
+// FormatOffset renders a duration compactly, e.g. 1d12h or 45m.
+func FormatOffset(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, strconv.Itoa(int(days))+"d")
+	}
+	if hours > 0 {
+		parts = append(parts, strconv.Itoa(int(hours))+"h")
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, strconv.Itoa(int(minutes))+"m")
+	}
+	return strings.Join(parts, "")
+}